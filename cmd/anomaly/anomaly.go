@@ -4,16 +4,28 @@ import (
 	"github.com/christian-gama/produgit/config"
 	"github.com/christian-gama/produgit/internal/anomaly"
 	"github.com/christian-gama/produgit/internal/data"
+	"github.com/christian-gama/produgit/internal/git"
+	"github.com/christian-gama/produgit/internal/logger"
 	dateutil "github.com/christian-gama/produgit/internal/util/date"
 	"github.com/spf13/cobra"
 )
 
 var (
-	quantity  int32
-	input     string
-	startDate string
-	endDate   string
-	authors   []string
+	quantity          int32
+	input             string
+	startDate         string
+	endDate           string
+	authors           []string
+	repos             []string
+	excludeRepos      []string
+	categories        []string
+	excludeCategories []string
+	projects          []string
+	excludeProjects   []string
+	credit            string
+	lines             string
+	noMerges          bool
+	groupBy           string
 )
 
 var AnomalyCmd = &cobra.Command{
@@ -26,6 +38,18 @@ var AnomalyCmd = &cobra.Command{
 		"-s",
 		"--end-date",
 		"-e",
+		"--repo",
+		"-r",
+		"--exclude-repo",
+		"--category",
+		"--exclude-category",
+		"--project",
+		"--exclude-project",
+		"--credit",
+		"--lines",
+		"--exclude-merges",
+		"--group-by",
+		"-g",
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		start, err := dateutil.ToTime(startDate)
@@ -38,23 +62,40 @@ var AnomalyCmd = &cobra.Command{
 			return err
 		}
 
+		if !cmd.Flags().Changed("exclude-merges") {
+			metadata, err := data.LoadMetadata(input)
+			if err != nil {
+				return err
+			}
+
+			// The lines of merged branches are counted twice in these reports, in the commits of
+			// the branches and in the merge commits.
+			if metadata.GetMergePolicy() == git.MergesSeparate {
+				noMerges = true
+				logger.Print("Excluding merge commits, as the report was generated with --merges separate. Use --exclude-merges=false to include them.")
+			}
+		}
+
 		cfg, err := anomaly.NewConfig(
 			start,
 			end,
 			quantity,
 			input,
 			authors,
+			groupBy,
+			data.WithIdentities(config.Config.Identities),
+			data.WithLines(lines),
+			data.WithCredit(credit),
+			data.WithRepos(repos, excludeRepos),
+			data.WithCategories(categories, excludeCategories),
+			data.WithProjects(projects, excludeProjects),
+			data.WithMerges(!noMerges),
 		)
 		if err != nil {
 			return err
 		}
 
-		logs, err := data.Load(input)
-		if err != nil {
-			return err
-		}
-
-		return anomaly.Anomaly(logs, cfg)
+		return anomaly.Anomaly(cfg)
 	},
 }
 
@@ -78,4 +119,50 @@ func Init() {
 	AnomalyCmd.
 		PersistentFlags().
 		StringSliceVarP(&authors, "authors", "a", config.Config.Authors, "Authors to be considered")
+
+	AnomalyCmd.
+		PersistentFlags().
+		StringSliceVarP(&repos, "repo", "r", []string{}, "Repositories to include, matching their path or remote")
+
+	AnomalyCmd.
+		PersistentFlags().
+		StringSliceVar(&excludeRepos, "exclude-repo", []string{}, "Repositories to exclude, matching their path or remote")
+
+	AnomalyCmd.
+		PersistentFlags().
+		StringSliceVar(&categories, "category", []string{}, "Categories of files to include: production, test, generated, vendored or documentation")
+
+	AnomalyCmd.
+		PersistentFlags().
+		StringSliceVar(&excludeCategories, "exclude-category", []string{}, "Categories of files to exclude")
+
+	AnomalyCmd.
+		PersistentFlags().
+		StringSliceVar(&projects, "project", []string{}, "Projects to include, or unassigned for the files without a project")
+
+	AnomalyCmd.
+		PersistentFlags().
+		StringSliceVar(&excludeProjects, "exclude-project", []string{}, "Projects to exclude")
+
+	AnomalyCmd.
+		PersistentFlags().
+		StringVar(&credit, "credit", data.CreditPrimary, "How the lines of commits with co-authors are credited: primary, split or full")
+
+	AnomalyCmd.
+		PersistentFlags().
+		StringVar(&lines, "lines", data.LinesRaw, "Line counts used: raw or effective, which ignores whitespace and moved code")
+
+	AnomalyCmd.
+		PersistentFlags().
+		BoolVar(&noMerges, "exclude-merges", false, "If true, the changes of merge commits are excluded. Defaults to true for reports generated with --merges separate, which would count merged branches twice")
+
+	AnomalyCmd.
+		PersistentFlags().
+		StringVarP(&groupBy, "group-by", "g", "", "Group the anomalies by repo, category or project")
+
+	if err := AnomalyCmd.RegisterFlagCompletionFunc("group-by", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return anomaly.GroupByOptions(), cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		panic(err)
+	}
 }
@@ -1,10 +1,11 @@
 package list
 
 import (
-	"fmt"
 	"sort"
 	"sync"
 
+	"github.com/christian-gama/produgit/config"
+	"github.com/christian-gama/produgit/internal/data"
 	"github.com/christian-gama/produgit/internal/git"
 	"github.com/spf13/cobra"
 )
@@ -13,18 +14,44 @@ var authorCmd = &cobra.Command{
 	Use:   "author",
 	Short: "List authors of all repositories",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateGroupBy(); err != nil {
+			return err
+		}
+
 		var authorsMu sync.Mutex
-		authors := make([]string, 0)
+		groups := make(map[string][]string)
+
+		err := git.WalkDirs(dir, walkOptions(), func(path string) error {
+			if !byProject() {
+				a, err := git.ListAllAuthors(path)
+				if err != nil {
+					return err
+				}
+
+				authorsMu.Lock()
+				groups[""] = append(groups[""], a...)
+				authorsMu.Unlock()
+
+				return nil
+			}
 
-		err := git.WalkDirs(dir, func(path string) error {
-			a, err := git.ListAllAuthors(path)
+			authorPaths, err := git.ListAuthorPaths(path)
 			if err != nil {
 				return err
 			}
 
-			authorsMu.Lock()
-			authors = append(authors, a...)
-			authorsMu.Unlock()
+			for author, paths := range authorPaths {
+				selected, err := selectedProjects(path, paths)
+				if err != nil {
+					return err
+				}
+
+				authorsMu.Lock()
+				for _, project := range selected {
+					groups[project] = append(groups[project], author)
+				}
+				authorsMu.Unlock()
+			}
 
 			return nil
 		})
@@ -32,10 +59,15 @@ var authorCmd = &cobra.Command{
 			return err
 		}
 
-		for _, author := range SortAndDeDuplicate(authors) {
-			fmt.Println(author)
+		for name, authors := range groups {
+			groups[name], err = data.ResolveIdentities(config.Config.Identities, authors)
+			if err != nil {
+				return err
+			}
 		}
 
+		printGroups(groups)
+
 		return nil
 	},
 }
@@ -1,10 +1,20 @@
 package list
 
 import (
+	"github.com/christian-gama/produgit/internal/git"
 	"github.com/spf13/cobra"
 )
 
-var dir []string
+var (
+	dir             []string
+	submodules      bool
+	maxDepth        int
+	skip            []string
+	followSymlinks  bool
+	projects        []string
+	excludeProjects []string
+	groupBy         string
+)
 
 var ListCmd = &cobra.Command{
 	Use:   "list",
@@ -15,6 +25,14 @@ var ListCmd = &cobra.Command{
 	ValidArgs: []string{
 		"--dir",
 		"-d",
+		"--submodules",
+		"--max-depth",
+		"--skip",
+		"--follow-symlinks",
+		"--project",
+		"--exclude-project",
+		"--group-by",
+		"-g",
 	},
 }
 
@@ -25,4 +43,42 @@ func Init() {
 	ListCmd.
 		PersistentFlags().
 		StringArrayVarP(&dir, "dir", "d", []string{"."}, "The starting directory to search for .git repositories")
+
+	ListCmd.
+		PersistentFlags().
+		BoolVar(&submodules, "submodules", true, "If true, submodules are included")
+
+	ListCmd.
+		PersistentFlags().
+		IntVar(&maxDepth, "max-depth", 0, "How many levels below each directory are searched, no limit when 0")
+
+	ListCmd.
+		PersistentFlags().
+		StringArrayVar(&skip, "skip", []string{"node_modules"}, "Names or globs of the directories never searched")
+
+	ListCmd.
+		PersistentFlags().
+		BoolVar(&followSymlinks, "follow-symlinks", false, "If true, symlinks to directories are followed")
+
+	ListCmd.
+		PersistentFlags().
+		StringSliceVar(&projects, "project", []string{}, "Projects to include, or unassigned for the files without a project")
+
+	ListCmd.
+		PersistentFlags().
+		StringSliceVar(&excludeProjects, "exclude-project", []string{}, "Projects to exclude")
+
+	ListCmd.
+		PersistentFlags().
+		StringVarP(&groupBy, "group-by", "g", "", "Group the output by project")
+}
+
+// walkOptions returns the options used to search the directories for repositories.
+func walkOptions() *git.WalkOptions {
+	return &git.WalkOptions{
+		Submodules:     submodules,
+		MaxDepth:       maxDepth,
+		Skip:           skip,
+		FollowSymlinks: followSymlinks,
+	}
 }
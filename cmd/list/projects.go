@@ -0,0 +1,94 @@
+package list
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/christian-gama/produgit/config"
+	"github.com/christian-gama/produgit/internal/data"
+	"github.com/christian-gama/produgit/internal/report"
+)
+
+// groupByProject is the only accepted value of the group by option of the list commands.
+const groupByProject = "project"
+
+// byProject reports whether the projects are needed, either to filter or to group the output.
+func byProject() bool {
+	return len(projects) > 0 || len(excludeProjects) > 0 || groupBy != ""
+}
+
+// validateGroupBy validates the group by option.
+func validateGroupBy() error {
+	if groupBy != "" && groupBy != groupByProject {
+		return fmt.Errorf("Group by must be one of %v", []string{groupByProject})
+	}
+	return nil
+}
+
+// selectedProjects returns the sorted projects of the paths of the repository that are selected
+// by the project options.
+func selectedProjects(repository string, paths []string) ([]string, error) {
+	repoProjects, err := report.RepoProjects(repository, config.Config.Projects)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := data.ResolveProjects(repoProjects, paths)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []string
+	for _, project := range SortAndDeDuplicate(resolved) {
+		if len(projects) > 0 && !contains(projects, project) {
+			continue
+		}
+
+		if contains(excludeProjects, project) {
+			continue
+		}
+
+		selected = append(selected, project)
+	}
+
+	return selected, nil
+}
+
+// printGroups prints the sorted and deduplicated values of each group under its name, or all of
+// them at once when the output is not grouped.
+func printGroups(groups map[string][]string) {
+	if groupBy == "" {
+		var values []string
+		for _, group := range groups {
+			values = append(values, group...)
+		}
+
+		for _, value := range SortAndDeDuplicate(values) {
+			fmt.Println(value)
+		}
+		return
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Println(name)
+		for _, value := range SortAndDeDuplicate(groups[name]) {
+			fmt.Printf("  %s\n", value)
+		}
+	}
+}
+
+// contains is a helper function to check if a slice contains a string.
+func contains(slice []string, str string) bool {
+	for _, s := range slice {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
@@ -1,8 +1,6 @@
 package list
 
 import (
-	"fmt"
-	"strings"
 	"sync"
 
 	"github.com/christian-gama/produgit/internal/git"
@@ -13,13 +11,36 @@ var reposCmd = &cobra.Command{
 	Use:   "repos",
 	Short: "List all repositories",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateGroupBy(); err != nil {
+			return err
+		}
+
 		var reposMu sync.Mutex
-		repos := make([]string, 0)
+		groups := make(map[string][]string)
+
+		err := git.WalkDirs(dir, walkOptions(), func(path string) error {
+			if !byProject() {
+				reposMu.Lock()
+				groups[""] = append(groups[""], path)
+				reposMu.Unlock()
+
+				return nil
+			}
+
+			files, err := git.MatchingFiles(cmd.Context(), path, ".")
+			if err != nil {
+				return err
+			}
+
+			selected, err := selectedProjects(path, files)
+			if err != nil {
+				return err
+			}
 
-		err := git.WalkDirs(dir, func(path string) error {
-			path = strings.TrimSuffix(path, "/.git")
 			reposMu.Lock()
-			repos = append(repos, path)
+			for _, project := range selected {
+				groups[project] = append(groups[project], path)
+			}
 			reposMu.Unlock()
 
 			return nil
@@ -28,9 +49,7 @@ var reposCmd = &cobra.Command{
 			return err
 		}
 
-		for _, repo := range SortAndDeDuplicate(repos) {
-			fmt.Println(repo)
-		}
+		printGroups(groups)
 
 		return nil
 	},
@@ -0,0 +1,14 @@
+package plot
+
+import (
+	"github.com/christian-gama/produgit/internal/plot"
+	"github.com/spf13/cobra"
+)
+
+var pairingCmd = &cobra.Command{
+	Use:   "pairing",
+	Short: "Plot the pairing matrix data from the report command",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return plot.NewPairing(logs, cfg).Plot()
+	},
+}
@@ -1,8 +1,12 @@
 package plot
 
 import (
+	"time"
+
 	"github.com/christian-gama/produgit/config"
 	"github.com/christian-gama/produgit/internal/data"
+	"github.com/christian-gama/produgit/internal/git"
+	"github.com/christian-gama/produgit/internal/logger"
 	"github.com/christian-gama/produgit/internal/plot"
 	dateutil "github.com/christian-gama/produgit/internal/util/date"
 	"github.com/spf13/cobra"
@@ -17,22 +21,63 @@ var PlotCmd = &cobra.Command{
 	Use:   "plot",
 	Short: "Plot the data from the report command",
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) (err error) {
-		logs, err = data.Load(input)
+		location, err := dateutil.ToLocation(timezone)
 		if err != nil {
 			return err
 		}
 
-		start, err := dateutil.ToTime(startDate)
+		dateLocation := location
+		if dateLocation == nil {
+			dateLocation = time.UTC
+		}
+
+		start, err := dateutil.ToTimeIn(startDate, dateLocation)
 		if err != nil {
 			return err
 		}
 
-		end, err := dateutil.ToTime(endDate)
+		end, err := dateutil.ToTimeIn(endDate, dateLocation)
 		if err != nil {
 			return err
 		}
 
-		cfg, err = plot.NewConfig(start, end, authors, period, output)
+		if !cmd.Flags().Changed("exclude-merges") {
+			metadata, err := data.LoadMetadata(input)
+			if err != nil {
+				return err
+			}
+
+			// The lines of merged branches are counted twice in these reports, in the commits of
+			// the branches and in the merge commits.
+			if metadata.GetMergePolicy() == git.MergesSeparate {
+				noMerges = true
+				logger.Print("Excluding merge commits, as the report was generated with --merges separate. Use --exclude-merges=false to include them.")
+			}
+		}
+
+		cfg, err = plot.NewConfig(
+			start,
+			end,
+			authors,
+			period,
+			output,
+			groupBy,
+			location,
+			[]data.FilterOption{
+				data.WithRepos(repos, excludeRepos),
+				data.WithCategories(categories, excludeCategories),
+				data.WithProjects(projects, excludeProjects),
+				data.WithMerges(!noMerges),
+			},
+			data.WithIdentities(config.Config.Identities),
+			data.WithLines(lines),
+			data.WithCredit(credit),
+		)
+		if err != nil {
+			return err
+		}
+
+		logs, err = data.LoadFiltered(input, cfg.LoadFilterOptions()...)
 		if err != nil {
 			return err
 		}
@@ -53,16 +98,40 @@ var PlotCmd = &cobra.Command{
 		"-a",
 		"--period",
 		"-p",
+		"--repo",
+		"-r",
+		"--exclude-repo",
+		"--category",
+		"--exclude-category",
+		"--project",
+		"--exclude-project",
+		"--credit",
+		"--lines",
+		"--exclude-merges",
+		"--group-by",
+		"-g",
+		"--tz",
 	},
 }
 
 var (
-	output    string
-	input     string
-	startDate string
-	endDate   string
-	authors   []string
-	period    string
+	output            string
+	input             string
+	startDate         string
+	endDate           string
+	authors           []string
+	period            string
+	repos             []string
+	excludeRepos      []string
+	categories        []string
+	excludeCategories []string
+	projects          []string
+	excludeProjects   []string
+	credit            string
+	lines             string
+	noMerges          bool
+	groupBy           string
+	timezone          string
 )
 
 func Init() {
@@ -71,6 +140,7 @@ func Init() {
 	PlotCmd.AddCommand(topLanguagesCmd)
 	PlotCmd.AddCommand(topAuthorsCmd)
 	PlotCmd.AddCommand(weekdayCmd)
+	PlotCmd.AddCommand(pairingCmd)
 
 	PlotCmd.
 		PersistentFlags().
@@ -96,6 +166,76 @@ func Init() {
 		PersistentFlags().
 		StringVarP(&period, "period", "p", "", "Period to plot")
 
+	PlotCmd.
+		PersistentFlags().
+		StringSliceVarP(&repos, "repo", "r", []string{}, "Repositories to include, matching their path or remote")
+
+	PlotCmd.
+		PersistentFlags().
+		StringSliceVar(&excludeRepos, "exclude-repo", []string{}, "Repositories to exclude, matching their path or remote")
+
+	PlotCmd.
+		PersistentFlags().
+		StringSliceVar(&categories, "category", []string{}, "Categories of files to include: production, test, generated, vendored or documentation")
+
+	PlotCmd.
+		PersistentFlags().
+		StringSliceVar(&excludeCategories, "exclude-category", []string{}, "Categories of files to exclude")
+
+	PlotCmd.
+		PersistentFlags().
+		StringSliceVar(&projects, "project", []string{}, "Projects to include, or unassigned for the files without a project")
+
+	PlotCmd.
+		PersistentFlags().
+		StringSliceVar(&excludeProjects, "exclude-project", []string{}, "Projects to exclude")
+
+	PlotCmd.
+		PersistentFlags().
+		StringVar(&credit, "credit", data.CreditPrimary, "How the lines of commits with co-authors are credited: primary, split or full")
+
+	PlotCmd.
+		PersistentFlags().
+		StringVar(&lines, "lines", data.LinesRaw, "Line counts used: raw or effective, which ignores whitespace and moved code")
+
+	PlotCmd.
+		PersistentFlags().
+		BoolVar(&noMerges, "exclude-merges", false, "If true, the changes of merge commits are excluded. Defaults to true for reports generated with --merges separate, which would count merged branches twice")
+
+	PlotCmd.
+		PersistentFlags().
+		StringVarP(&groupBy, "group-by", "g", plot.GroupByAuthor, "Group the series by author, repo, category or project")
+
+	PlotCmd.
+		PersistentFlags().
+		StringVar(&timezone, "tz", dateutil.AuthorTimezone, "Timezone used to bucket dates: author, utc, local or an IANA timezone")
+
+	if err := PlotCmd.RegisterFlagCompletionFunc("group-by", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return plot.GroupByOptions(), cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		panic(err)
+	}
+
+	if err := PlotCmd.RegisterFlagCompletionFunc("credit", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return data.CreditPolicies(), cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		panic(err)
+	}
+
+	if err := PlotCmd.RegisterFlagCompletionFunc("lines", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return data.LineKinds(), cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		panic(err)
+	}
+
+	for _, flag := range []string{"category", "exclude-category"} {
+		if err := PlotCmd.RegisterFlagCompletionFunc(flag, func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return data.Categories(), cobra.ShellCompDirectiveNoFileComp
+		}); err != nil {
+			panic(err)
+		}
+	}
+
 	if err := PlotCmd.RegisterFlagCompletionFunc("period", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return []string{"today", "24h", "this_week", "7d", "this_month", "30d", "this_year", "1y"}, cobra.ShellCompDirectiveNoFileComp
 	}); err != nil {
@@ -0,0 +1,22 @@
+package report
+
+import (
+	"os"
+
+	"github.com/christian-gama/produgit/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <old report> <new report>",
+	Short: "Show what changed between two reports",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		diff, err := report.DiffReports(args[0], args[1])
+		if err != nil {
+			return err
+		}
+
+		return diff.Print(os.Stdout)
+	},
+}
@@ -0,0 +1,118 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/christian-gama/produgit/config"
+	"github.com/christian-gama/produgit/internal/report"
+	dateutil "github.com/christian-gama/produgit/internal/util/date"
+	"github.com/spf13/cobra"
+)
+
+var keep int
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Manage the snapshots of previous reports",
+	ValidArgs: []string{
+		"--history",
+	},
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if history == "" {
+			return fmt.Errorf("History directory must be set with --history or in the config.")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+var historyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the snapshots, from the oldest to the newest",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		snapshots, err := report.ListSnapshots(history)
+		if err != nil {
+			return err
+		}
+
+		if len(snapshots) == 0 {
+			fmt.Println("No snapshots found")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tGENERATED AT\tSIZE")
+		for _, snapshot := range snapshots {
+			fmt.Fprintf(
+				w,
+				"%s\t%s\t%s\n",
+				snapshot.Name,
+				dateutil.ToString(snapshot.Time.Local()),
+				strconv.FormatInt(snapshot.Size, 10),
+			)
+		}
+
+		return w.Flush()
+	},
+}
+
+var historyRestoreCmd = &cobra.Command{
+	Use:   "restore <snapshot>",
+	Short: "Restore a snapshot as the report",
+	Args:  cobra.ExactArgs(1),
+	ValidArgs: []string{
+		"--output",
+		"-o",
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := report.RestoreSnapshot(history, args[0], output); err != nil {
+			return err
+		}
+
+		fmt.Printf("Restored %s to %s\n", args[0], output)
+		return nil
+	},
+}
+
+var historyPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove the snapshots but the newest ones",
+	ValidArgs: []string{
+		"--keep",
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pruned, err := report.PruneSnapshots(history, keep)
+		if err != nil {
+			return err
+		}
+
+		for _, snapshot := range pruned {
+			fmt.Printf("Removed %s\n", snapshot.Name)
+		}
+
+		return nil
+	},
+}
+
+func initHistory() {
+	historyCmd.AddCommand(historyListCmd)
+	historyCmd.AddCommand(historyRestoreCmd)
+	historyCmd.AddCommand(historyPruneCmd)
+
+	historyCmd.
+		PersistentFlags().
+		StringVar(&history, "history", config.Config.Report.History, "The directory holding the snapshots")
+
+	historyRestoreCmd.
+		Flags().
+		StringVarP(&output, "output", "o", config.Config.Report.Output, "The output path for the report")
+
+	historyPruneCmd.
+		Flags().
+		IntVar(&keep, "keep", 10, "The number of newest snapshots to keep")
+}
@@ -0,0 +1,121 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/christian-gama/produgit/config"
+	"github.com/christian-gama/produgit/internal/data"
+	dateutil "github.com/christian-gama/produgit/internal/util/date"
+	"github.com/spf13/cobra"
+)
+
+var input string
+
+var infoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Print how a report was generated",
+	ValidArgs: []string{
+		"--input",
+		"-i",
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		report, err := data.LoadReport(input)
+		if err != nil {
+			return err
+		}
+
+		metadata := report.GetMetadata()
+
+		generatedAt := "Unknown"
+		if metadata.GetGeneratedAt() != nil {
+			generatedAt = dateutil.ToString(metadata.GetGeneratedAt().AsTime().Local())
+		}
+
+		repositories := make(map[string]bool)
+		commits := make(map[string]bool)
+		hashless := 0
+		for _, log := range report.GetLogs() {
+			repositories[log.RepositoryName()] = true
+			if log.GetHash() == "" {
+				hashless++
+				continue
+			}
+			commits[log.GetHash()] = true
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "Schema version:\t%d\n", metadata.GetSchemaVersion())
+		fmt.Fprintf(w, "Generated at:\t%s\n", generatedAt)
+		fmt.Fprintf(w, "Produgit version:\t%s\n", orUnknown(metadata.GetProdugitVersion()))
+		fmt.Fprintf(w, "Directories:\t%s\n", orUnknown(strings.Join(metadata.GetDirs(), ", ")))
+		fmt.Fprintf(w, "Excludes:\t%s\n", orUnknown(strings.Join(metadata.GetExcludes(), ", ")))
+		fmt.Fprintf(w, "Merge policy:\t%s\n", orUnknown(metadata.GetMergePolicy()))
+		fmt.Fprintf(w, "Incremental:\t%t\n", metadata.GetIncremental())
+		fmt.Fprintf(w, "Submodules:\t%t\n", metadata.GetSubmodules())
+		if len(metadata.GetLogSources()) > 0 {
+			fmt.Fprintf(w, "Log sources:\t%s\n", strings.Join(metadata.GetLogSources(), ", "))
+		}
+		if metadata.GetSince() != nil {
+			fmt.Fprintf(w, "Since:\t%s\n", dateutil.ToString(metadata.GetSince().AsTime()))
+		}
+		if metadata.GetUntil() != nil {
+			fmt.Fprintf(w, "Until:\t%s\n", dateutil.ToString(metadata.GetUntil().AsTime()))
+		}
+		if metadata.GetEffectiveLines() != "" {
+			fmt.Fprintf(w, "Effective lines:\t%s\n", metadata.GetEffectiveLines())
+		}
+		if len(metadata.GetRefs()) > 0 {
+			fmt.Fprintf(w, "Refs:\t%s\n", strings.Join(metadata.GetRefs(), ", "))
+		}
+		fmt.Fprintf(w, "Repositories:\t%d\n", len(repositories))
+		fmt.Fprintf(w, "Commits:\t%s\n", commitCount(len(commits), hashless))
+		fmt.Fprintf(w, "Logs:\t%d\n", len(report.GetLogs()))
+
+		for _, source := range metadata.GetSources() {
+			sourceGeneratedAt := "Unknown"
+			if source.GetGeneratedAt() != nil {
+				sourceGeneratedAt = dateutil.ToString(source.GetGeneratedAt().AsTime().Local())
+			}
+
+			fmt.Fprintf(
+				w,
+				"Merged from:\t%s (generated at %s, %d logs)\n",
+				source.GetPath(),
+				sourceGeneratedAt,
+				source.GetLogs(),
+			)
+		}
+
+		return w.Flush()
+	},
+}
+
+// orUnknown returns "Unknown" for the metadata missing in reports generated by older versions.
+func orUnknown(value string) string {
+	if value == "" {
+		return "Unknown"
+	}
+	return value
+}
+
+// commitCount describes the number of commits, which cannot be counted for the logs of reports
+// generated by older versions without commit hashes.
+func commitCount(commits, hashless int) string {
+	switch {
+	case hashless == 0:
+		return fmt.Sprintf("%d", commits)
+	case commits == 0:
+		return "Unknown, the logs have no commit hashes"
+	default:
+		return fmt.Sprintf("%d, plus %d logs without a commit hash", commits, hashless)
+	}
+}
+
+func initInfo() {
+	infoCmd.
+		Flags().
+		StringVarP(&input, "input", "i", config.Config.Report.Output, "Input file")
+}
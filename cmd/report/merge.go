@@ -0,0 +1,40 @@
+package report
+
+import (
+	"github.com/christian-gama/produgit/config"
+	"github.com/christian-gama/produgit/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var mergeOutput string
+
+var mergeCmd = &cobra.Command{
+	Use:   "merge <report> <report>...",
+	Short: "Merge several reports into one",
+	Args:  cobra.MinimumNArgs(2),
+	ValidArgs: []string{
+		"--output",
+		"-o",
+		"--compress",
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return report.Merge(args, mergeOutput, &report.MergeOptions{
+			Identities: config.Config.Identities,
+			Compress:   compress,
+		})
+	},
+}
+
+func initMerge() {
+	mergeCmd.
+		Flags().
+		StringVarP(&mergeOutput, "output", "o", "", "The output path for the merged report")
+
+	if err := mergeCmd.MarkFlagRequired("output"); err != nil {
+		panic(err)
+	}
+
+	mergeCmd.
+		Flags().
+		BoolVar(&compress, "compress", false, "If true, the merged report is compressed with gzip")
+}
@@ -1,15 +1,43 @@
 package report
 
 import (
+	"os"
+	"os/signal"
+	"runtime"
+	"syscall"
+	"time"
+
 	"github.com/christian-gama/produgit/config"
+	"github.com/christian-gama/produgit/internal/data"
+	"github.com/christian-gama/produgit/internal/git"
 	"github.com/christian-gama/produgit/internal/report"
+	dateutil "github.com/christian-gama/produgit/internal/util/date"
 	"github.com/spf13/cobra"
 )
 
 var (
-	dir     []string
-	output  string
-	exclude []string
+	dir         []string
+	output      string
+	exclude     []string
+	incremental bool
+	submodules  bool
+	merges      string
+	workers     int
+	timeout     time.Duration
+	strict      bool
+	compress    bool
+	history     string
+	fromLogs    []string
+	repoName    string
+	dryRun      bool
+	maxDepth    int
+	skip        []string
+	symlinks    bool
+	since       string
+	until       string
+	refs        git.RefSelection
+	effective   string
+	verbose     bool
 )
 
 var ReportCmd = &cobra.Command{
@@ -24,14 +52,86 @@ var ReportCmd = &cobra.Command{
 		"-e",
 		"--quiet",
 		"-q",
+		"--incremental",
+		"--submodules",
+		"--merges",
+		"--workers",
+		"--timeout",
+		"--strict",
+		"--compress",
+		"--history",
+		"--from-log",
+		"--repo-name",
+		"--dry-run",
+		"--max-depth",
+		"--skip",
+		"--follow-symlinks",
+		"--since",
+		"--until",
+		"--all",
+		"--branches",
+		"--branch",
+		"--remotes",
+		"--tags",
+		"--effective-lines",
+		"--verbose",
+		"-v",
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		report := report.NewReport(dir, exclude, output)
-		return report.Generate()
+		sinceDate, err := dateutil.ToTime(since)
+		if err != nil {
+			return err
+		}
+
+		untilDate, err := dateutil.ToTime(until)
+		if err != nil {
+			return err
+		}
+
+		// Failures past this point are not caused by the usage, so it is not printed along with
+		// the summary of the repositories that failed.
+		cmd.SilenceUsage = true
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		report := report.NewReport(
+			dir,
+			exclude,
+			output,
+			report.WithIncremental(incremental),
+			report.WithSubmodules(submodules),
+			report.WithIdentities(config.Config.Identities),
+			report.WithProjects(config.Config.Projects),
+			report.WithMerges(merges),
+			report.WithWorkers(workers),
+			report.WithTimeout(timeout),
+			report.WithStrict(strict),
+			report.WithCompress(compress),
+			report.WithHistory(history),
+			report.WithFromLogs(fromLogs, repoName),
+			report.WithDryRun(dryRun),
+			report.WithMaxDepth(maxDepth),
+			report.WithSkip(skip),
+			report.WithSymlinks(symlinks),
+			report.WithDateRange(sinceDate, untilDate),
+			report.WithRefs(&refs),
+			report.WithEffective(effective),
+			report.WithVerbose(verbose),
+		)
+		return report.Generate(ctx)
 	},
 }
 
 func Init() {
+	ReportCmd.AddCommand(infoCmd)
+	ReportCmd.AddCommand(historyCmd)
+	ReportCmd.AddCommand(mergeCmd)
+	ReportCmd.AddCommand(diffCmd)
+	initInfo()
+	initHistory()
+	initMerge()
+
 	ReportCmd.
 		Flags().
 		StringArrayVarP(&dir, "dir", "d", []string{"."}, "The starting directory to search for .git repositories")
@@ -47,4 +147,108 @@ func Init() {
 	ReportCmd.
 		Flags().
 		BoolVarP(&config.Config.Quiet, "quiet", "q", config.Config.Quiet, "If true, the report will not be printed to stdout")
+
+	ReportCmd.
+		Flags().
+		BoolVar(&incremental, "incremental", false, "If true, only the commits newer than the previous report are fetched")
+
+	ReportCmd.
+		Flags().
+		BoolVar(&submodules, "submodules", true, "If true, submodules are included in the report")
+
+	ReportCmd.
+		Flags().
+		StringVar(&merges, "merges", git.MergesDefault, "Policy for merge commits: default, exclude, first-parent or separate, whose merge commits plot and anomaly exclude by default, as they count the lines of merged branches twice")
+
+	ReportCmd.
+		Flags().
+		IntVar(&workers, "workers", runtime.NumCPU(), "The number of repositories processed concurrently")
+
+	ReportCmd.
+		Flags().
+		DurationVar(&timeout, "timeout", 0, "The time each repository is given to be processed, e.g. 5m; no limit when 0")
+
+	ReportCmd.
+		Flags().
+		BoolVar(&strict, "strict", false, "If true, the report stops at the first repository that fails")
+
+	ReportCmd.
+		Flags().
+		BoolVar(&compress, "compress", false, "If true, the report is compressed with gzip")
+
+	ReportCmd.
+		Flags().
+		StringVar(&history, "history", config.Config.Report.History, "The directory where a snapshot of each report is kept")
+
+	ReportCmd.
+		Flags().
+		StringArrayVar(&fromLogs, "from-log", nil, "Files holding a pre-captured git log to read instead of searching the directories, or - for stdin")
+
+	ReportCmd.
+		Flags().
+		StringVar(&repoName, "repo-name", "", "The repository name of the logs read with --from-log, defaults to the file name")
+
+	ReportCmd.
+		Flags().
+		BoolVar(&dryRun, "dry-run", false, "If true, the repositories that would be processed are listed without writing the report")
+
+	ReportCmd.
+		Flags().
+		IntVar(&maxDepth, "max-depth", 0, "How many levels below each directory are searched, no limit when 0")
+
+	ReportCmd.
+		Flags().
+		StringArrayVar(&skip, "skip", []string{"node_modules"}, "Names or globs of the directories never searched")
+
+	ReportCmd.
+		Flags().
+		BoolVar(&symlinks, "follow-symlinks", false, "If true, symlinks to directories are followed")
+
+	ReportCmd.
+		Flags().
+		StringVar(&since, "since", "", "Only report the commits committed at or after this date")
+
+	ReportCmd.
+		Flags().
+		StringVar(&until, "until", "", "Only report the commits committed at or before this date")
+
+	ReportCmd.
+		Flags().
+		BoolVar(&refs.All, "all", false, "If true, the history of every ref is reported instead of HEAD")
+
+	ReportCmd.
+		Flags().
+		BoolVar(&refs.Branches, "branches", false, "If true, the history of every local branch is reported instead of HEAD")
+
+	ReportCmd.
+		Flags().
+		StringArrayVar(&refs.Branch, "branch", []string{}, "Names or globs of the local branches whose history is reported instead of HEAD")
+
+	ReportCmd.
+		Flags().
+		BoolVar(&refs.Remotes, "remotes", false, "If true, the history of every remote-tracking branch is reported instead of HEAD")
+
+	ReportCmd.
+		Flags().
+		BoolVar(&refs.Tags, "tags", false, "If true, the history of every tag is reported instead of HEAD")
+
+	ReportCmd.
+		Flags().
+		StringVar(&effective, "effective-lines", data.EffectiveOff, "Effective line counts stored along with the raw ones: off, whitespace or moves")
+
+	ReportCmd.
+		Flags().
+		BoolVarP(&verbose, "verbose", "v", false, "If true, the effective configuration of each repository is printed")
+
+	if err := ReportCmd.RegisterFlagCompletionFunc("merges", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return git.MergePolicies(), cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		panic(err)
+	}
+
+	if err := ReportCmd.RegisterFlagCompletionFunc("effective-lines", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return data.EffectiveModes(), cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		panic(err)
+	}
 }
@@ -46,6 +46,7 @@ func DefaultPlotOutputPath() string {
 type report struct {
 	Exclude []string `toml:"exclude"`
 	Output  string   `toml:"output"`
+	History string   `toml:"history"`
 }
 
 // plot is the configuration for the plot command.
@@ -55,10 +56,12 @@ type plot struct {
 
 // config is the configuration for the produgit command.
 type config struct {
-	Report  *report  `toml:"report"`
-	Plot    *plot    `toml:"plot"`
-	Quiet   bool     `toml:"quiet"`
-	Authors []string `toml:"authors"`
+	Report     *report             `toml:"report"`
+	Plot       *plot               `toml:"plot"`
+	Quiet      bool                `toml:"quiet"`
+	Authors    []string            `toml:"authors"`
+	Identities map[string][]string `toml:"identities"`
+	Projects   map[string][]string `toml:"projects"`
 }
 
 // New creates a new Config with default values.
@@ -129,8 +132,10 @@ func New() (*config, error) {
 		Plot: &plot{
 			Output: DefaultPlotOutputPath(),
 		},
-		Quiet:   false,
-		Authors: []string{},
+		Quiet:      false,
+		Authors:    []string{},
+		Identities: map[string][]string{},
+		Projects:   map[string][]string{},
 	}
 
 	return cfg, nil
@@ -2,11 +2,29 @@ package anomaly
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/christian-gama/produgit/internal/data"
 )
 
+const (
+	// GroupByRepo groups the anomalies by repository.
+	GroupByRepo = "repo"
+
+	// GroupByCategory groups the anomalies by the category of the files.
+	GroupByCategory = "category"
+
+	// GroupByProject groups the anomalies by project.
+	GroupByProject = "project"
+)
+
+// GroupByOptions returns the accepted values for the group by option, which is empty to list
+// the anomalies without grouping them.
+func GroupByOptions() []string {
+	return []string{GroupByRepo, GroupByCategory, GroupByProject}
+}
+
 // Config represents the configuration for the anomaly command.
 type Config struct {
 	startDate time.Time
@@ -14,6 +32,8 @@ type Config struct {
 	quantity  int32
 	input     string
 	authors   []string
+	groupBy   string
+	filters   []data.FilterOption
 }
 
 // NewConfig creates a new Config.
@@ -22,6 +42,8 @@ func NewConfig(
 	quantity int32,
 	input string,
 	authors []string,
+	groupBy string,
+	filters ...data.FilterOption,
 ) (*Config, error) {
 	if endDate.IsZero() {
 		endDate = time.Now()
@@ -35,47 +57,104 @@ func NewConfig(
 		return nil, fmt.Errorf("No authors provided")
 	}
 
+	if groupBy != "" && !contains(GroupByOptions(), groupBy) {
+		return nil, fmt.Errorf("Group by must be one of %v", GroupByOptions())
+	}
+
 	cfg := &Config{
 		startDate: startDate,
 		endDate:   endDate,
 		quantity:  quantity,
 		input:     input,
 		authors:   authors,
+		groupBy:   groupBy,
+		filters:   filters,
 	}
 
 	return cfg, nil
 }
 
-// Anomaly prints the anomalies found in the logs.
-func Anomaly(l *data.Logs, config *Config) error {
-	logs, err := data.Filter(
-		l,
-		data.WithDate(config.startDate, config.endDate),
-		data.WithAuthors(config.authors),
+// Anomaly prints the anomalies found in the logs of the input report. The logs are filtered
+// while the report is read, so only the ones matching the filters are held in memory.
+func Anomaly(config *Config) error {
+	logs, err := data.LoadFiltered(
+		config.input,
+		append(
+			append(
+				[]data.FilterOption{data.WithDate(config.startDate, config.endDate)},
+				config.filters...,
+			),
+			data.WithAuthors(config.authors),
+		)...,
 	)
 	if err != nil {
 		return err
 	}
 
-	found := false
+	var anomalies []*data.Log
 	for _, log := range logs.Logs {
 		if log.Plus > config.quantity {
-			if !found {
-				fmt.Printf("%-6s - %-15s - %s\n", "Plus", "Author", "Path")
-			}
-			fmt.Printf(
-				"%-6d - %-15s - %s\n",
-				log.Plus,
-				fmt.Sprintf("%.15s", log.Author),
-				log.Path,
-			)
-			found = true
+			anomalies = append(anomalies, log)
 		}
 	}
 
-	if !found {
+	if len(anomalies) == 0 {
 		fmt.Println("No anomalies found")
+		return nil
+	}
+
+	sort.SliceStable(anomalies, func(i, j int) bool {
+		return config.groupKey(anomalies[i]) < config.groupKey(anomalies[j])
+	})
+
+	fmt.Printf(
+		"%-6s - %-15s - %-25s - %-13s - %s\n",
+		"Plus",
+		"Author",
+		"Repository",
+		"Category",
+		"Path",
+	)
+	for i, log := range anomalies {
+		key := config.groupKey(log)
+		if config.groupBy != "" && (i == 0 || key != config.groupKey(anomalies[i-1])) {
+			fmt.Printf("\n%s:\n", key)
+		}
+
+		fmt.Printf(
+			"%-6d - %-15s - %-25s - %-13s - %s\n",
+			log.Plus,
+			fmt.Sprintf("%.15s", log.Author),
+			fmt.Sprintf("%.25s", log.RepositoryName()),
+			log.CategoryName(),
+			log.Path,
+		)
 	}
 
 	return nil
 }
+
+// groupKey returns the name of the group the log belongs to, which is empty when the anomalies
+// are not grouped.
+func (c *Config) groupKey(l *data.Log) string {
+	switch c.groupBy {
+	case GroupByRepo:
+		return l.RepositoryName()
+	case GroupByCategory:
+		return l.CategoryName()
+	case GroupByProject:
+		return l.ProjectName()
+	default:
+		return ""
+	}
+}
+
+// contains is a helper function to check if a slice contains a string.
+func contains(slice []string, str string) bool {
+	for _, s := range slice {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,176 @@
+package data
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+const (
+	// CategoryProduction is the code written by the authors that is none of the other categories.
+	CategoryProduction = "production"
+
+	// CategoryTest is the code of tests and their fixtures.
+	CategoryTest = "test"
+
+	// CategoryGenerated is the code generated by tools, like protobuf files, mocks, snapshots and
+	// lock files.
+	CategoryGenerated = "generated"
+
+	// CategoryVendored is the third party code copied into the repository.
+	CategoryVendored = "vendored"
+
+	// CategoryDocumentation is the documentation, like READMEs and the docs directory.
+	CategoryDocumentation = "documentation"
+)
+
+// Categories returns the accepted categories of the files.
+func Categories() []string {
+	return []string{
+		CategoryProduction,
+		CategoryTest,
+		CategoryGenerated,
+		CategoryVendored,
+		CategoryDocumentation,
+	}
+}
+
+// categoryPatterns holds the known patterns of each category, matched against the name of the
+// file, and the known directories, matched against every directory of its path.
+var categoryPatterns = map[string]struct {
+	names []string
+	dirs  []string
+}{
+	CategoryVendored: {
+		dirs: []string{"vendor", "vendors", "node_modules", "third_party", "third-party", "bower_components", "Godeps"},
+	},
+	CategoryGenerated: {
+		names: []string{
+			"*.pb.go", "*.pb.gw.go", "*_pb2.py", "*_pb2_grpc.py", "*.pb.cc", "*.pb.h", "*_pb.js", "*_pb.d.ts",
+			"*.gen.go", "*_gen.go", "*_generated.*", "*.generated.*", "zz_generated*",
+			"mock_*.go", "*_mock.go", "*_mocks.go", "*.snap", "*.min.js", "*.min.css",
+			"go.sum", "package-lock.json", "yarn.lock", "pnpm-lock.yaml", "Cargo.lock",
+			"Gemfile.lock", "poetry.lock", "composer.lock", "Pipfile.lock",
+		},
+		dirs: []string{"mocks", "__snapshots__", "__generated__"},
+	},
+	CategoryTest: {
+		names: []string{
+			"*_test.go", "test_*.py", "*_test.py", "*.test.*", "*.spec.*", "*_spec.rb",
+			"*Test.java", "*Tests.java", "*Test.kt", "*Tests.cs", "*Test.php",
+		},
+		dirs: []string{"test", "tests", "__tests__", "spec", "testdata", "fixtures"},
+	},
+	CategoryDocumentation: {
+		names: []string{
+			"*.md", "*.markdown", "*.rst", "*.adoc", "*.asciidoc",
+			"README*", "CHANGELOG*", "CONTRIBUTING*", "LICENSE*", "COPYING*", "AUTHORS*",
+		},
+		dirs: []string{"docs", "doc", "documentation"},
+	},
+}
+
+// FileHints holds what is known about a file besides its path, like its .gitattributes. Each
+// hint is nil when unknown, and otherwise overrides the known patterns of its category.
+type FileHints struct {
+	Generated     *bool
+	Vendored      *bool
+	Documentation *bool
+}
+
+// Classify returns the category of the file at the given path. The categories are checked in
+// the order vendored, generated, test and documentation, and a file matching none of them is
+// production code.
+func Classify(filePath string, hints *FileHints) string {
+	if hints == nil {
+		hints = &FileHints{}
+	}
+
+	switch {
+	case isCategory(filePath, CategoryVendored, hints.Vendored):
+		return CategoryVendored
+	case isCategory(filePath, CategoryGenerated, hints.Generated):
+		return CategoryGenerated
+	case isCategory(filePath, CategoryTest, nil):
+		return CategoryTest
+	case isCategory(filePath, CategoryDocumentation, hints.Documentation):
+		return CategoryDocumentation
+	default:
+		return CategoryProduction
+	}
+}
+
+// isCategory reports whether the file belongs to the category, according to the hint when it is
+// known or to the known patterns of the category otherwise.
+func isCategory(filePath, category string, hint *bool) bool {
+	if hint != nil {
+		return *hint
+	}
+
+	patterns := categoryPatterns[category]
+
+	name := path.Base(filePath)
+	for _, pattern := range patterns.names {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+
+	dirs := strings.Split(path.Dir(filePath), "/")
+	for _, dir := range dirs {
+		for _, pattern := range patterns.dirs {
+			if dir == pattern {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// WithCategories filters logs by the category of their file. A log is kept when its category is
+// any of the included categories, or all of them when none is included, and none of the excluded
+// categories.
+func WithCategories(include, exclude []string) FilterOption {
+	return func(logs []*Log) ([]*Log, error) {
+		if len(include) == 0 && len(exclude) == 0 {
+			return logs, nil
+		}
+
+		for _, category := range append(append([]string{}, include...), exclude...) {
+			if !containsString(Categories(), category) {
+				return nil, fmt.Errorf("Category must be one of %v", Categories())
+			}
+		}
+
+		var filteredLogs []*Log
+		for _, log := range logs {
+			category := log.CategoryName()
+			if len(include) > 0 && !containsString(include, category) {
+				continue
+			}
+
+			if containsString(exclude, category) {
+				continue
+			}
+
+			filteredLogs = append(filteredLogs, log)
+		}
+
+		if len(filteredLogs) == 0 {
+			return nil, noLogsErrorf("No logs found for the selected categories.")
+		}
+
+		return filteredLogs, nil
+	}
+}
+
+// containsString reports whether the slice contains the string.
+func containsString(slice []string, str string) bool {
+	for _, s := range slice {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
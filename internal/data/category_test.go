@@ -0,0 +1,98 @@
+package data
+
+import (
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	yes, no := true, false
+
+	tests := []struct {
+		name     string
+		path     string
+		hints    *FileHints
+		expected string
+	}{
+		{name: "production", path: "internal/data/loader.go", expected: CategoryProduction},
+		{name: "test", path: "internal/data/loader_test.go", expected: CategoryTest},
+		{name: "test directory", path: "src/__tests__/app.js", expected: CategoryTest},
+		{name: "generated", path: "internal/data/log.pb.go", expected: CategoryGenerated},
+		{name: "snapshot", path: "src/__tests__/__snapshots__/app.js.snap", expected: CategoryGenerated},
+		{name: "vendored", path: "vendor/github.com/spf13/cobra/command.go", expected: CategoryVendored},
+		{name: "documentation", path: "docs/guide.md", expected: CategoryDocumentation},
+		{name: "readme", path: "README", expected: CategoryDocumentation},
+		{
+			name:     "generated attribute",
+			path:     "internal/api/client.go",
+			hints:    &FileHints{Generated: &yes},
+			expected: CategoryGenerated,
+		},
+		{
+			name:     "unset vendored attribute",
+			path:     "vendor/lib/lib.go",
+			hints:    &FileHints{Vendored: &no},
+			expected: CategoryProduction,
+		},
+		{
+			name:     "unset documentation attribute",
+			path:     "docs/site/main.go",
+			hints:    &FileHints{Documentation: &no},
+			expected: CategoryProduction,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Classify(tt.path, tt.hints); got != tt.expected {
+				t.Errorf("Classify(%q) = %q, expected %q", tt.path, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFilter_WithCategories(t *testing.T) {
+	newLogs := func() *Logs {
+		return &Logs{
+			Logs: []*Log{
+				{Path: "main.go", Category: CategoryProduction},
+				{Path: "main_test.go", Category: CategoryTest},
+				{Path: "log.pb.go"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		expected []string
+		wantErr  bool
+	}{
+		{name: "no categories", expected: []string{"main.go", "main_test.go", "log.pb.go"}},
+		{name: "include", include: []string{CategoryTest}, expected: []string{"main_test.go"}},
+		{name: "exclude classified by path", exclude: []string{CategoryGenerated}, expected: []string{"main.go", "main_test.go"}},
+		{name: "no logs", include: []string{CategoryVendored}, wantErr: true},
+		{name: "invalid category", include: []string{"handwritten"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Filter(newLogs(), WithCategories(tt.include, tt.exclude))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Filter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(got.Logs) != len(tt.expected) {
+				t.Fatalf("Filter() returned %d logs, expected %d", len(got.Logs), len(tt.expected))
+			}
+			for i, log := range got.Logs {
+				if log.GetPath() != tt.expected[i] {
+					t.Errorf("Filter() log %d = %q, expected %q", i, log.GetPath(), tt.expected[i])
+				}
+			}
+		})
+	}
+}
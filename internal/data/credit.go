@@ -0,0 +1,72 @@
+package data
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// CreditPrimary credits the lines only to the author of the commit.
+	CreditPrimary = "primary"
+
+	// CreditSplit splits the lines evenly between the author and the co-authors of the commit.
+	CreditSplit = "split"
+
+	// CreditFull credits all the lines to the author and to each co-author of the commit.
+	CreditFull = "full"
+)
+
+// CreditPolicies returns the accepted crediting policies.
+func CreditPolicies() []string {
+	return []string{CreditPrimary, CreditSplit, CreditFull}
+}
+
+// WithCredit credits the lines of each log to its co-authors according to the given policy.
+// The logs credited to a co-author are copies of the original log with the co-author as the
+// author.
+func WithCredit(policy string) FilterOption {
+	return func(logs []*Log) ([]*Log, error) {
+		switch policy {
+		case "", CreditPrimary:
+			return logs, nil
+		case CreditSplit, CreditFull:
+		default:
+			return nil, fmt.Errorf("Credit policy must be one of %v", CreditPolicies())
+		}
+
+		var creditedLogs []*Log
+		for _, log := range logs {
+			if len(log.GetCoAuthors()) == 0 {
+				creditedLogs = append(creditedLogs, log)
+				continue
+			}
+
+			authors := append([]string{log.GetAuthor()}, log.GetCoAuthors()...)
+			for i, author := range authors {
+				credited := proto.Clone(log).(*Log)
+				credited.Author = author
+
+				if policy == CreditSplit {
+					credited.Plus = splitCredit(log.GetPlus(), len(authors), i)
+					credited.Minus = splitCredit(log.GetMinus(), len(authors), i)
+					credited.Diff = credited.Plus - credited.Minus
+				}
+
+				creditedLogs = append(creditedLogs, credited)
+			}
+		}
+
+		return creditedLogs, nil
+	}
+}
+
+// splitCredit returns the share of the value credited to the author at the given index,
+// giving the remainder of the division to the primary author.
+func splitCredit(value int32, authors int, index int) int32 {
+	share := value / int32(authors)
+	if index == 0 {
+		share += value % int32(authors)
+	}
+	return share
+}
@@ -0,0 +1,85 @@
+package data
+
+import (
+	"testing"
+)
+
+func TestFilter_WithCredit(t *testing.T) {
+	newLogs := func() *Logs {
+		return &Logs{
+			Logs: []*Log{
+				{Author: "John", Plus: 10, Minus: 4, Diff: 6, CoAuthors: []string{"Jane", "Doe"}},
+				{Author: "Jane", Plus: 3, Minus: 0, Diff: 3},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		policy   string
+		expected []*Log
+		wantErr  bool
+	}{
+		{
+			name:   "primary",
+			policy: CreditPrimary,
+			expected: []*Log{
+				{Author: "John", Plus: 10, Minus: 4, Diff: 6},
+				{Author: "Jane", Plus: 3, Minus: 0, Diff: 3},
+			},
+		},
+		{
+			name:   "split",
+			policy: CreditSplit,
+			expected: []*Log{
+				{Author: "John", Plus: 4, Minus: 2, Diff: 2},
+				{Author: "Jane", Plus: 3, Minus: 1, Diff: 2},
+				{Author: "Doe", Plus: 3, Minus: 1, Diff: 2},
+				{Author: "Jane", Plus: 3, Minus: 0, Diff: 3},
+			},
+		},
+		{
+			name:   "full",
+			policy: CreditFull,
+			expected: []*Log{
+				{Author: "John", Plus: 10, Minus: 4, Diff: 6},
+				{Author: "Jane", Plus: 10, Minus: 4, Diff: 6},
+				{Author: "Doe", Plus: 10, Minus: 4, Diff: 6},
+				{Author: "Jane", Plus: 3, Minus: 0, Diff: 3},
+			},
+		},
+		{
+			name:    "invalid policy",
+			policy:  "half",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Filter(newLogs(), WithCredit(tt.policy))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Filter() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got == nil {
+				return
+			}
+
+			if len(got.Logs) != len(tt.expected) {
+				t.Errorf("Filter() got = %v, want %v", got, tt.expected)
+				return
+			}
+
+			for i, want := range tt.expected {
+				if got.Logs[i].GetAuthor() != want.GetAuthor() ||
+					got.Logs[i].GetPlus() != want.GetPlus() ||
+					got.Logs[i].GetMinus() != want.GetMinus() ||
+					got.Logs[i].GetDiff() != want.GetDiff() {
+					t.Errorf("Filter() got = %v, want %v", got.Logs[i], want)
+				}
+			}
+		})
+	}
+}
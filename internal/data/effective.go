@@ -0,0 +1,300 @@
+package data
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	// EffectiveOff does not compute the effective line counts.
+	EffectiveOff = "off"
+
+	// EffectiveWhitespace computes the effective line counts ignoring the changes of whitespace
+	// and blank lines, like reformatting.
+	EffectiveWhitespace = "whitespace"
+
+	// EffectiveMoves computes the effective line counts ignoring the changes of whitespace and
+	// the blocks of code moved within a commit, even across files.
+	EffectiveMoves = "moves"
+)
+
+// EffectiveModes returns the accepted modes for the effective line counts.
+func EffectiveModes() []string {
+	return []string{EffectiveOff, EffectiveWhitespace, EffectiveMoves}
+}
+
+const (
+	// LinesRaw uses the line counts of git log --numstat.
+	LinesRaw = "raw"
+
+	// LinesEffective uses the effective line counts.
+	LinesEffective = "effective"
+)
+
+// LineKinds returns the accepted kinds of line counts.
+func LineKinds() []string {
+	return []string{LinesRaw, LinesEffective}
+}
+
+// movedMinAlnum is the number of alphanumeric characters a block of lines must have to be
+// considered moved, as in git diff --color-moved, so lone braces are not.
+const movedMinAlnum = 20
+
+// effectiveKey identifies the file changed by a commit.
+type effectiveKey struct {
+	hash string
+	path string
+}
+
+// EffectiveCounts holds the effective line counts of the files changed by each commit.
+type EffectiveCounts map[effectiveKey]*LineCounts
+
+// EffectiveParser parses the patches streamed by git.StreamPatch line by line into the
+// effective line counts of each file, holding the lines of a single commit at a time. When moves
+// is true, the blocks of lines added that were removed elsewhere in the same commit are not
+// counted, neither where they were added nor where they were removed.
+type EffectiveParser struct {
+	moves  bool
+	counts EffectiveCounts
+	commit *patchCommit
+}
+
+// NewEffectiveParser creates a new EffectiveParser.
+func NewEffectiveParser(moves bool) *EffectiveParser {
+	return &EffectiveParser{moves: moves, counts: make(EffectiveCounts)}
+}
+
+// ParseLine parses the next line of the patches, counting the lines of the previous commit when
+// the line starts a new one.
+func (p *EffectiveParser) ParseLine(line string) {
+	if strings.HasPrefix(line, "\x1e") {
+		p.commit.count(p.counts, p.moves)
+		p.commit = &patchCommit{hash: strings.TrimSpace(line[1:])}
+		return
+	}
+
+	p.commit.parseLine(line)
+}
+
+// Counts returns the effective line counts of the patches parsed.
+func (p *EffectiveParser) Counts() EffectiveCounts {
+	p.commit.count(p.counts, p.moves)
+	p.commit = nil
+	return p.counts
+}
+
+// ParseEffective parses the lines of the patches at once into the effective line counts of each
+// file, as EffectiveParser does.
+func ParseEffective(rawPatch []string, moves bool) EffectiveCounts {
+	parser := NewEffectiveParser(moves)
+	for _, line := range rawPatch {
+		parser.ParseLine(line)
+	}
+	return parser.Counts()
+}
+
+// Apply sets the effective line counts of the logs. The files missing from the patches, whose
+// changes were all whitespace, have no effective lines.
+func (c EffectiveCounts) Apply(logs []*Log) {
+	for _, log := range logs {
+		effective := &LineCounts{}
+		if counts, ok := c[effectiveKey{hash: log.GetHash(), path: log.GetPath()}]; ok {
+			effective.Plus = counts.GetPlus()
+			effective.Minus = counts.GetMinus()
+		}
+		log.Effective = effective
+	}
+}
+
+// WithLines selects the line counts of the logs, replacing the raw counts with the effective
+// ones for LinesEffective. Logs without effective counts, from reports generated without them,
+// keep their raw counts.
+func WithLines(kind string) FilterOption {
+	return func(logs []*Log) ([]*Log, error) {
+		switch kind {
+		case "", LinesRaw:
+			return logs, nil
+		case LinesEffective:
+		default:
+			return nil, fmt.Errorf("Lines must be one of %v", LineKinds())
+		}
+
+		for _, log := range logs {
+			if effective := log.GetEffective(); effective != nil {
+				log.Plus = effective.GetPlus()
+				log.Minus = effective.GetMinus()
+				log.Diff = log.Plus - log.Minus
+			}
+		}
+
+		return logs, nil
+	}
+}
+
+// patchCommit holds the files changed by a commit while its patch is parsed.
+type patchCommit struct {
+	hash   string
+	files  []*patchFile
+	inHunk bool
+}
+
+// patchFile holds the lines changed in a file by a commit.
+type patchFile struct {
+	path    string
+	oldPath string
+	plus    int32
+	minus   int32
+	removed []string
+
+	// added holds the runs of consecutive lines added.
+	added     [][]string
+	lastAdded bool
+}
+
+// parseLine parses a line of the patch of the commit.
+func (c *patchCommit) parseLine(line string) {
+	if c == nil {
+		return
+	}
+
+	if strings.HasPrefix(line, "diff --git ") {
+		c.files = append(c.files, &patchFile{})
+		c.inHunk = false
+		return
+	}
+
+	if len(c.files) == 0 {
+		return
+	}
+	file := c.files[len(c.files)-1]
+
+	if strings.HasPrefix(line, "@@") {
+		c.inHunk = true
+		file.lastAdded = false
+		return
+	}
+
+	if !c.inHunk {
+		switch {
+		case strings.HasPrefix(line, "rename to "):
+			file.path = unquotePath(strings.TrimPrefix(line, "rename to "))
+		case strings.HasPrefix(line, "--- "):
+			file.oldPath = headerPath(strings.TrimPrefix(line, "--- "), "a/")
+		case line == "+++ /dev/null":
+			file.path = file.oldPath
+		case strings.HasPrefix(line, "+++ "):
+			file.path = headerPath(strings.TrimPrefix(line, "+++ "), "b/")
+		}
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(line, "+"):
+		file.plus++
+		if !file.lastAdded {
+			file.added = append(file.added, nil)
+		}
+		file.added[len(file.added)-1] = append(file.added[len(file.added)-1], line[1:])
+		file.lastAdded = true
+	case strings.HasPrefix(line, "-"):
+		file.minus++
+		file.removed = append(file.removed, line[1:])
+		file.lastAdded = false
+	default:
+		file.lastAdded = false
+	}
+}
+
+// headerPath returns the path of a "---" or "+++" line of a patch without its prefix. Git ends
+// these lines with a tab when the path contains a space.
+func headerPath(value, prefix string) string {
+	return strings.TrimPrefix(unquotePath(strings.TrimSuffix(value, "\t")), prefix)
+}
+
+// count adds the line counts of the files of the commit to the counts.
+func (c *patchCommit) count(counts EffectiveCounts, moves bool) {
+	if c == nil {
+		return
+	}
+
+	if moves {
+		c.removeMoves()
+	}
+
+	for _, file := range c.files {
+		if file.path == "" {
+			continue
+		}
+
+		key := effectiveKey{hash: c.hash, path: file.path}
+		if counts[key] == nil {
+			counts[key] = &LineCounts{}
+		}
+		counts[key].Plus += file.plus
+		counts[key].Minus += file.minus
+	}
+}
+
+// removeMoves leaves out of the counts the blocks of lines added that were removed elsewhere in
+// the commit, along with the lines they were removed from.
+func (c *patchCommit) removeMoves() {
+	removed := make(map[string][]*patchFile)
+	for _, file := range c.files {
+		for _, line := range file.removed {
+			key := normalizeLine(line)
+			removed[key] = append(removed[key], file)
+		}
+	}
+
+	for _, file := range c.files {
+		for _, run := range file.added {
+			for start := 0; start < len(run); {
+				taken := make(map[string]int)
+				end := start
+				for end < len(run) {
+					key := normalizeLine(run[end])
+					if taken[key] >= len(removed[key]) {
+						break
+					}
+					taken[key]++
+					end++
+				}
+
+				if end == start {
+					start++
+					continue
+				}
+
+				if alnumCount(run[start:end]) >= movedMinAlnum {
+					for _, line := range run[start:end] {
+						key := normalizeLine(line)
+						removed[key][0].minus--
+						removed[key] = removed[key][1:]
+						file.plus--
+					}
+				}
+
+				start = end
+			}
+		}
+	}
+}
+
+// normalizeLine removes the whitespace of the line, which is ignored by the patches.
+func normalizeLine(line string) string {
+	return strings.Join(strings.Fields(line), "")
+}
+
+// alnumCount returns the number of alphanumeric characters of the lines.
+func alnumCount(lines []string) int {
+	count := 0
+	for _, line := range lines {
+		for _, r := range line {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				count++
+			}
+		}
+	}
+	return count
+}
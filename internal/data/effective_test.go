@@ -0,0 +1,157 @@
+package data
+
+import (
+	"testing"
+)
+
+func TestParseEffective(t *testing.T) {
+	rawPatch := []string{
+		"\x1eabc",
+		"",
+		"diff --git a/old.go b/old.go",
+		"index 1111111..2222222 100644",
+		"--- a/old.go",
+		"+++ b/old.go",
+		"@@ -1,4 +1,1 @@",
+		" package main",
+		"-func helper() string {",
+		"-\treturn \"moved helper\"",
+		"-}",
+		"diff --git a/new.go b/new.go",
+		"new file mode 100644",
+		"index 0000000..3333333",
+		"--- /dev/null",
+		"+++ b/new.go",
+		"@@ -0,0 +1,5 @@",
+		"+package main",
+		"+",
+		"+func helper() string {",
+		"+    return \"moved helper\"",
+		"+}",
+		"diff --git a/gone.go b/gone.go",
+		"deleted file mode 100644",
+		"--- a/gone.go",
+		"+++ /dev/null",
+		"@@ -1 +0,0 @@",
+		"-}",
+		"\x1edef",
+		"diff --git a/a.go b/b.go",
+		"similarity index 100%",
+		"rename from a.go",
+		"rename to b.go",
+		"\x1eghi",
+		"diff --git a/dir one/f.go b/dir one/f.go",
+		"new file mode 100644",
+		"index 0000000..4444444",
+		"--- /dev/null",
+		"+++ b/dir one/f.go\t",
+		"@@ -0,0 +1,2 @@",
+		"+a",
+		"+b",
+		"diff --git a/dir two/g.go b/dir two/g.go",
+		"index 5555555..6666666 100644",
+		"--- a/dir two/g.go\t",
+		"+++ b/dir two/g.go\t",
+		"@@ -1 +1 @@",
+		"-a",
+		"+c",
+	}
+
+	tests := []struct {
+		name     string
+		moves    bool
+		expected map[effectiveKey]*LineCounts
+	}{
+		{
+			name:  "whitespace",
+			moves: false,
+			expected: map[effectiveKey]*LineCounts{
+				{hash: "abc", path: "old.go"}:       {Plus: 0, Minus: 3},
+				{hash: "abc", path: "new.go"}:       {Plus: 5, Minus: 0},
+				{hash: "abc", path: "gone.go"}:      {Plus: 0, Minus: 1},
+				{hash: "def", path: "b.go"}:         {Plus: 0, Minus: 0},
+				{hash: "ghi", path: "dir one/f.go"}: {Plus: 2, Minus: 0},
+				{hash: "ghi", path: "dir two/g.go"}: {Plus: 1, Minus: 1},
+			},
+		},
+		{
+			name:  "moves",
+			moves: true,
+			expected: map[effectiveKey]*LineCounts{
+				{hash: "abc", path: "old.go"}:       {Plus: 0, Minus: 0},
+				{hash: "abc", path: "new.go"}:       {Plus: 2, Minus: 0},
+				{hash: "abc", path: "gone.go"}:      {Plus: 0, Minus: 1},
+				{hash: "def", path: "b.go"}:         {Plus: 0, Minus: 0},
+				{hash: "ghi", path: "dir one/f.go"}: {Plus: 2, Minus: 0},
+				{hash: "ghi", path: "dir two/g.go"}: {Plus: 1, Minus: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseEffective(rawPatch, tt.moves)
+
+			if len(got) != len(tt.expected) {
+				t.Fatalf("ParseEffective() returned %d files, expected %d", len(got), len(tt.expected))
+			}
+
+			for key, expected := range tt.expected {
+				counts := got[key]
+				if counts.GetPlus() != expected.GetPlus() || counts.GetMinus() != expected.GetMinus() {
+					t.Errorf(
+						"ParseEffective()[%v] = %d/%d, expected %d/%d",
+						key,
+						counts.GetPlus(),
+						counts.GetMinus(),
+						expected.GetPlus(),
+						expected.GetMinus(),
+					)
+				}
+			}
+		})
+	}
+}
+
+func TestFilter_WithLines(t *testing.T) {
+	newLogs := func() *Logs {
+		return &Logs{
+			Logs: []*Log{
+				{Author: "John", Plus: 10, Minus: 4, Diff: 6, Effective: &LineCounts{Plus: 2, Minus: 1}},
+				{Author: "Jane", Plus: 3, Minus: 0, Diff: 3},
+			},
+		}
+	}
+
+	t.Run("raw", func(t *testing.T) {
+		got, err := Filter(newLogs(), WithLines(LinesRaw))
+		if err != nil {
+			t.Fatalf("Filter() error = %v", err)
+		}
+
+		if got.Logs[0].GetPlus() != 10 || got.Logs[0].GetDiff() != 6 {
+			t.Errorf("Filter() changed the raw counts: %v", got.Logs[0])
+		}
+	})
+
+	t.Run("effective", func(t *testing.T) {
+		got, err := Filter(newLogs(), WithLines(LinesEffective))
+		if err != nil {
+			t.Fatalf("Filter() error = %v", err)
+		}
+
+		if got.Logs[0].GetPlus() != 2 || got.Logs[0].GetMinus() != 1 || got.Logs[0].GetDiff() != 1 {
+			t.Errorf("Filter() did not use the effective counts: %v", got.Logs[0])
+		}
+
+		if got.Logs[1].GetPlus() != 3 || got.Logs[1].GetDiff() != 3 {
+			t.Errorf("Filter() changed the logs without effective counts: %v", got.Logs[1])
+		}
+	})
+
+	t.Run("invalid kind", func(t *testing.T) {
+		if _, err := Filter(newLogs(), WithLines("cooked")); err == nil {
+			t.Error("Filter() expected an error")
+		}
+	})
+}
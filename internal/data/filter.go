@@ -1,6 +1,7 @@
 package data
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -9,6 +10,27 @@ import (
 	dateutil "github.com/christian-gama/produgit/internal/util/date"
 )
 
+// ErrNoLogs is matched by the errors of the filter options leaving no logs.
+var ErrNoLogs = errors.New("No logs found.")
+
+// noLogsError is the error of a filter option leaving no logs, which matches ErrNoLogs.
+type noLogsError struct {
+	message string
+}
+
+// noLogsErrorf creates a noLogsError with the formatted message.
+func noLogsErrorf(format string, args ...interface{}) error {
+	return &noLogsError{message: fmt.Sprintf(format, args...)}
+}
+
+func (e *noLogsError) Error() string {
+	return e.message
+}
+
+func (e *noLogsError) Is(target error) bool {
+	return target == ErrNoLogs
+}
+
 // FilterOption represents a filter option.
 type FilterOption func(logs []*Log) ([]*Log, error)
 
@@ -57,7 +79,7 @@ func WithDate(startTime, endTime time.Time) FilterOption {
 		}
 
 		if len(filteredLogs) == 0 {
-			return nil, fmt.Errorf(
+			return nil, noLogsErrorf(
 				"No logs found between %s and %s.",
 				dateutil.ToString(startTime),
 				dateutil.ToString(endTime),
@@ -87,7 +109,7 @@ func WithAuthors(authors []string) FilterOption {
 		}
 
 		if len(filteredLogs) == 0 {
-			return nil, fmt.Errorf("No logs found for authors %s.", authors)
+			return nil, noLogsErrorf("No logs found for authors %s.", authors)
 		}
 
 		return filteredLogs, nil
@@ -126,3 +148,89 @@ func WithMergeAuthors(authors []string) FilterOption {
 		return filteredLogs, nil
 	}
 }
+
+// WithRepos filters logs by repository. A log is kept when its repository path or remote
+// matches any of the included repositories, or all of them when none is included, and none of
+// the excluded repositories.
+func WithRepos(include, exclude []string) FilterOption {
+	return func(logs []*Log) ([]*Log, error) {
+		if len(include) == 0 && len(exclude) == 0 {
+			return logs, nil
+		}
+
+		includeRegexes, err := compileRepoRegexes(include)
+		if err != nil {
+			return nil, err
+		}
+
+		excludeRegexes, err := compileRepoRegexes(exclude)
+		if err != nil {
+			return nil, err
+		}
+
+		var filteredLogs []*Log
+		for _, log := range logs {
+			if len(includeRegexes) > 0 && !matchRepo(includeRegexes, log) {
+				continue
+			}
+
+			if matchRepo(excludeRegexes, log) {
+				continue
+			}
+
+			filteredLogs = append(filteredLogs, log)
+		}
+
+		if len(filteredLogs) == 0 {
+			return nil, noLogsErrorf("No logs found for the selected repositories.")
+		}
+
+		return filteredLogs, nil
+	}
+}
+
+// compileRepoRegexes compiles the repository patterns as case insensitive regexes.
+func compileRepoRegexes(repos []string) ([]*regexp.Regexp, error) {
+	regexes := make([]*regexp.Regexp, 0, len(repos))
+	for _, repo := range repos {
+		r, err := regexp.Compile(fmt.Sprintf("(?i)%s", repo))
+		if err != nil {
+			return nil, fmt.Errorf("Repository expected to be a valid regex: %s.", repo)
+		}
+		regexes = append(regexes, r)
+	}
+
+	return regexes, nil
+}
+
+// matchRepo checks if any of the regexes matches the repository path or remote of the log.
+func matchRepo(regexes []*regexp.Regexp, log *Log) bool {
+	for _, r := range regexes {
+		if r.MatchString(log.GetRepository()) || r.MatchString(log.GetRemote()) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithMerges filters out the logs of merge commits unless include is true.
+func WithMerges(include bool) FilterOption {
+	return func(logs []*Log) ([]*Log, error) {
+		if include {
+			return logs, nil
+		}
+
+		var filteredLogs []*Log
+		for _, log := range logs {
+			if !log.GetMerge() {
+				filteredLogs = append(filteredLogs, log)
+			}
+		}
+
+		if len(filteredLogs) == 0 {
+			return nil, noLogsErrorf("No logs found excluding merge commits.")
+		}
+
+		return filteredLogs, nil
+	}
+}
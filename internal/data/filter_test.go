@@ -320,3 +320,106 @@ func TestFilter_WithMergeAuthors(t *testing.T) {
 		})
 	}
 }
+
+func TestFilter_WithRepos(t *testing.T) {
+	logs := &Logs{
+		Logs: []*Log{
+			{Repository: "/work/billing", Remote: "github.com/acme/billing"},
+			{Repository: "/work/auth", Remote: "github.com/acme/auth"},
+			{Repository: "/personal/dotfiles"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		expected []string
+		wantErr  bool
+	}{
+		{
+			name:     "no filter",
+			expected: []string{"/work/billing", "/work/auth", "/personal/dotfiles"},
+		},
+		{
+			name:     "include by remote",
+			include:  []string{"ACME/billing"},
+			expected: []string{"/work/billing"},
+		},
+		{
+			name:     "include by path",
+			include:  []string{"^/personal"},
+			expected: []string{"/personal/dotfiles"},
+		},
+		{
+			name:     "exclude",
+			exclude:  []string{"auth"},
+			expected: []string{"/work/billing", "/personal/dotfiles"},
+		},
+		{
+			name:     "include and exclude",
+			include:  []string{"acme"},
+			exclude:  []string{"auth"},
+			expected: []string{"/work/billing"},
+		},
+		{
+			name:    "no match",
+			include: []string{"unknown"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid regex",
+			include: []string{"acme("},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Filter(logs, WithRepos(tt.include, tt.exclude))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Filter() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got == nil {
+				return
+			}
+
+			if len(got.Logs) != len(tt.expected) {
+				t.Errorf("Filter() got = %v, want %v", got, tt.expected)
+				return
+			}
+
+			for i := range got.Logs {
+				if got.Logs[i].GetRepository() != tt.expected[i] {
+					t.Errorf("Filter() got = %v, want %v", got, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestFilter_WithMerges(t *testing.T) {
+	logs := &Logs{
+		Logs: []*Log{
+			{Hash: "a1", Merge: true},
+			{Hash: "b2"},
+		},
+	}
+
+	got, err := Filter(logs, WithMerges(true))
+	if err != nil || len(got.GetLogs()) != 2 {
+		t.Errorf("Filter() got = %v, err = %v", got, err)
+	}
+
+	got, err = Filter(logs, WithMerges(false))
+	if err != nil || len(got.GetLogs()) != 1 || got.GetLogs()[0].GetHash() != "b2" {
+		t.Errorf("Filter() got = %v, err = %v", got, err)
+	}
+
+	_, err = Filter(&Logs{Logs: []*Log{{Merge: true}}}, WithMerges(false))
+	if err == nil {
+		t.Errorf("Filter() expected an error")
+	}
+}
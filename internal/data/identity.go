@@ -0,0 +1,130 @@
+package data
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var contactRegex = regexp.MustCompile(`^(.*?)\s*<([^>]*)>$`)
+
+// FormatIdentity formats a name and an email as "Name (email)".
+func FormatIdentity(name, email string) string {
+	if name == "" {
+		name = "Unknown Name"
+	}
+
+	if email == "" {
+		email = "Unknown Email"
+	}
+
+	return strings.TrimSpace(fmt.Sprintf("%s (%s)", name, email))
+}
+
+// SplitIdentity splits a "Name (email)" identity into its name and email.
+func SplitIdentity(author string) (name string, email string) {
+	i := strings.LastIndex(author, " (")
+	if i < 0 || !strings.HasSuffix(author, ")") {
+		return author, ""
+	}
+	return author[:i], author[i+2 : len(author)-1]
+}
+
+// ParseContact parses a "Name <email>" contact, as used by git, into a "Name (email)" identity.
+func ParseContact(contact string) string {
+	if matches := contactRegex.FindStringSubmatch(contact); len(matches) == 3 {
+		return FormatIdentity(matches[1], matches[2])
+	}
+	return FormatIdentity(contact, "")
+}
+
+// identity holds a canonical identity and the regexes matching its aliases.
+type identity struct {
+	canonical string
+	aliases   []*regexp.Regexp
+}
+
+// identityResolver resolves aliases to their canonical identities.
+type identityResolver []identity
+
+// newIdentityResolver compiles the aliases of each canonical identity. Each alias is a case
+// insensitive regex that must match the whole name, email or "Name (email)" identity.
+func newIdentityResolver(identities map[string][]string) (identityResolver, error) {
+	canonicals := make([]string, 0, len(identities))
+	for canonical := range identities {
+		canonicals = append(canonicals, canonical)
+	}
+	sort.Strings(canonicals)
+
+	resolver := make(identityResolver, 0, len(canonicals))
+	for _, canonical := range canonicals {
+		id := identity{canonical: canonical}
+		for _, alias := range identities[canonical] {
+			r, err := regexp.Compile(fmt.Sprintf("(?i)^(?:%s)$", alias))
+			if err != nil {
+				return nil, fmt.Errorf("Identity alias expected to be a valid regex: %s.", alias)
+			}
+			id.aliases = append(id.aliases, r)
+		}
+		resolver = append(resolver, id)
+	}
+
+	return resolver, nil
+}
+
+// resolve returns the canonical identity of the given "Name (email)" identity, or the identity
+// itself when none of the aliases match.
+func (r identityResolver) resolve(author string) string {
+	name, email := SplitIdentity(author)
+
+	for _, id := range r {
+		for _, alias := range id.aliases {
+			if alias.MatchString(author) || alias.MatchString(name) || alias.MatchString(email) {
+				return id.canonical
+			}
+		}
+	}
+
+	return author
+}
+
+// ResolveIdentities resolves each of the given identities to its canonical identity.
+func ResolveIdentities(identities map[string][]string, authors []string) ([]string, error) {
+	resolver, err := newIdentityResolver(identities)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make([]string, 0, len(authors))
+	for _, author := range authors {
+		resolved = append(resolved, resolver.resolve(author))
+	}
+
+	return resolved, nil
+}
+
+// WithIdentities replaces the author, the committer and the co-authors of the logs with their
+// canonical identities.
+func WithIdentities(identities map[string][]string) FilterOption {
+	return func(logs []*Log) ([]*Log, error) {
+		if len(identities) == 0 {
+			return logs, nil
+		}
+
+		resolver, err := newIdentityResolver(identities)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, log := range logs {
+			log.Author = resolver.resolve(log.GetAuthor())
+			log.Committer = resolver.resolve(log.GetCommitter())
+			for i, coAuthor := range log.GetCoAuthors() {
+				log.CoAuthors[i] = resolver.resolve(coAuthor)
+			}
+		}
+
+		return logs, nil
+	}
+}
@@ -0,0 +1,52 @@
+package data
+
+import (
+	"testing"
+)
+
+func TestFilter_WithIdentities(t *testing.T) {
+	identities := map[string][]string{
+		"John Doe (john@work.com)": {"john@laptop.local", "JD", `.*\(john\.doe@.*\)`},
+		"Jane (jane@work.com)":     {"jane"},
+	}
+
+	tests := []struct {
+		name     string
+		author   string
+		expected string
+	}{
+		{"match email", "John (john@laptop.local)", "John Doe (john@work.com)"},
+		{"match name case insensitive", "jd (jd@home.com)", "John Doe (john@work.com)"},
+		{"match regex", "Johnny (john.doe@gmail.com)", "John Doe (john@work.com)"},
+		{"partial match is ignored", "Janet (janet@home.com)", "Janet (janet@home.com)"},
+		{"match name", "Jane (jane@home.com)", "Jane (jane@work.com)"},
+		{"no match", "Bob (bob@work.com)", "Bob (bob@work.com)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs := &Logs{
+				Logs: []*Log{{Author: tt.author, Committer: tt.author, CoAuthors: []string{tt.author}}},
+			}
+
+			got, err := Filter(logs, WithIdentities(identities))
+			if err != nil {
+				t.Fatalf("Filter() error = %v", err)
+			}
+
+			log := got.Logs[0]
+			if log.GetAuthor() != tt.expected ||
+				log.GetCommitter() != tt.expected ||
+				log.GetCoAuthors()[0] != tt.expected {
+				t.Errorf("Filter() got = %v, want %s", log, tt.expected)
+			}
+		})
+	}
+
+	if _, err := Filter(
+		&Logs{Logs: []*Log{{Author: "John"}}},
+		WithIdentities(map[string][]string{"John": {"john("}}),
+	); err == nil {
+		t.Errorf("Filter() expected an error for an invalid alias")
+	}
+}
@@ -1,22 +1,157 @@
 package data
 
 import (
-	"os"
-
-	"google.golang.org/protobuf/proto"
+	"errors"
+	"fmt"
 )
 
+// SchemaVersion is the schema version of the reports written by this version of produgit.
+// Reports written before the metadata header was introduced have schema version 1.
+const SchemaVersion = 2
+
+// migrations upgrades a report from the schema version of the key to the next one.
+var migrations = map[uint32]func(report *Report) error{
+	1: migrateV1,
+}
+
 // Load loads the logs from the report.
 func Load(reportPath string) (*Logs, error) {
-	file, err := os.ReadFile(reportPath)
+	return LoadFiltered(reportPath)
+}
+
+// LoadFiltered loads the logs of the report matching the filter options. The options are
+// applied to the logs of each repository as they are read, so the logs filtered out are never
+// held in memory all at once. Thus the options must decide on each log alone, as the options of
+// this package do.
+func LoadFiltered(reportPath string, options ...FilterOption) (*Logs, error) {
+	reader, err := OpenReport(reportPath)
 	if err != nil {
 		return nil, err
 	}
+	defer reader.Close()
 
 	logs := &Logs{}
-	if err := proto.Unmarshal(file, logs); err != nil {
+	var noLogsErr error
+	var chunk []*Log
+
+	flush := func() error {
+		if len(chunk) == 0 || len(options) == 0 {
+			logs.Logs = append(logs.Logs, chunk...)
+			chunk = nil
+			return nil
+		}
+
+		filtered, err := Filter(&Logs{Logs: chunk}, options...)
+		chunk = nil
+		if errors.Is(err, ErrNoLogs) {
+			noLogsErr = err
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		logs.Logs = append(logs.Logs, filtered.Logs...)
+		return nil
+	}
+
+	current := reader.Chunk()
+	for reader.Next() {
+		if reader.Chunk() != current {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			current = reader.Chunk()
+		}
+
+		chunk = append(chunk, reader.Log())
+	}
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := flush(); err != nil {
 		return nil, err
 	}
 
+	if len(logs.Logs) == 0 && noLogsErr != nil {
+		return nil, noLogsErr
+	}
+
 	return logs, nil
 }
+
+// LoadMetadata loads the metadata of the report, migrated to the current schema version.
+func LoadMetadata(reportPath string) (*Metadata, error) {
+	reader, err := OpenReport(reportPath)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return reader.Metadata(), nil
+}
+
+// LoadReport loads the report with its checkpoints and metadata, migrating it to the current
+// schema version.
+func LoadReport(reportPath string) (*Report, error) {
+	reader, err := OpenReport(reportPath)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	report := &Report{Metadata: reader.Metadata()}
+	for reader.Next() {
+		report.Logs = append(report.Logs, reader.Log())
+	}
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+	report.Checkpoints = reader.Checkpoints()
+
+	return report, nil
+}
+
+// Migrate upgrades the report to the current schema version. Reports written by a newer
+// version of produgit are rejected, as they may hold data this version does not understand.
+func Migrate(report *Report) error {
+	if report.Metadata == nil {
+		report.Metadata = &Metadata{}
+	}
+
+	version := report.Metadata.GetSchemaVersion()
+	if version == 0 {
+		version = 1
+	}
+
+	if version > SchemaVersion {
+		return fmt.Errorf(
+			"Report schema version %d is newer than the supported version %d, please upgrade produgit.",
+			version,
+			SchemaVersion,
+		)
+	}
+
+	for ; version < SchemaVersion; version++ {
+		if err := migrations[version](report); err != nil {
+			return fmt.Errorf("Migrating report from schema version %d failed: %w", version, err)
+		}
+	}
+
+	report.Metadata.SchemaVersion = SchemaVersion
+
+	return nil
+}
+
+// migrateV1 migrates the reports that held only the logs, the checkpoints and the merge
+// policy, which was empty for the reports generated before merge policies existed.
+func migrateV1(report *Report) error {
+	if report.Metadata.GetMergePolicy() == "" {
+		report.Metadata.MergePolicy = "default"
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestLoadReport(t *testing.T) {
+	writeReport := func(t *testing.T, message proto.Message) string {
+		t.Helper()
+
+		content, err := proto.Marshal(message)
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+
+		path := filepath.Join(t.TempDir(), "report.pb")
+		if err := os.WriteFile(path, content, 0600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+
+		return path
+	}
+
+	t.Run("migrates legacy logs", func(t *testing.T) {
+		path := writeReport(t, &Logs{Logs: []*Log{{Hash: "a1"}, {Hash: "b2"}}})
+
+		report, err := LoadReport(path)
+		if err != nil {
+			t.Fatalf("LoadReport() error = %v", err)
+		}
+
+		if len(report.GetLogs()) != 2 ||
+			report.GetMetadata().GetSchemaVersion() != SchemaVersion ||
+			report.GetMetadata().GetMergePolicy() != "default" {
+			t.Errorf("LoadReport() got = %v", report)
+		}
+	})
+
+	t.Run("keeps current reports", func(t *testing.T) {
+		path := writeReport(t, &Report{
+			Logs:     []*Log{{Hash: "a1"}},
+			Metadata: &Metadata{SchemaVersion: SchemaVersion, MergePolicy: "exclude"},
+		})
+
+		logs, err := Load(path)
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+
+		if len(logs.GetLogs()) != 1 {
+			t.Errorf("Load() got = %v", logs)
+		}
+
+		report, err := LoadReport(path)
+		if err != nil || report.GetMetadata().GetMergePolicy() != "exclude" {
+			t.Errorf("LoadReport() got = %v, err = %v", report, err)
+		}
+	})
+
+	t.Run("rejects newer reports", func(t *testing.T) {
+		path := writeReport(t, &Report{Metadata: &Metadata{SchemaVersion: SchemaVersion + 1}})
+
+		if _, err := LoadReport(path); err == nil {
+			t.Errorf("LoadReport() expected an error")
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package data
+
+import "time"
+
+// RepositoryName returns the name identifying the repository of the log, which is the
+// normalized remote URL or the path of the repository when it does not have a remote.
+func (x *Log) RepositoryName() string {
+	if x.GetRemote() != "" {
+		return x.GetRemote()
+	}
+	return x.GetRepository()
+}
+
+// Time returns the date of the log in the given location, or in the timezone of the author
+// when the location is nil.
+func (x *Log) Time(loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.FixedZone("", int(x.GetTzOffset())*60)
+	}
+	return x.GetDate().AsTime().In(loc)
+}
+
+// CategoryName returns the category of the file of the log. Logs of reports generated before
+// the files were classified are classified by their path.
+func (x *Log) CategoryName() string {
+	if x.GetCategory() != "" {
+		return x.GetCategory()
+	}
+	return Classify(x.GetPath(), nil)
+}
+
+// ProjectName returns the project of the log, which is ProjectUnassigned when its path is not
+// mapped to any project.
+func (x *Log) ProjectName() string {
+	if x.GetProject() != "" {
+		return x.GetProject()
+	}
+	return ProjectUnassigned
+}
+
+// SourceName returns the repository the log was found in, which differs from its repository when
+// the repository was collapsed into a clone or fork of the same project.
+func (x *Log) SourceName() string {
+	if x.GetSource() != "" {
+		return x.GetSource()
+	}
+	return x.GetRepository()
+}
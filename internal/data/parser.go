@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/christian-gama/produgit/internal/logger"
@@ -12,41 +13,52 @@ import (
 )
 
 var (
-	headerRegex  = regexp.MustCompile(`^'(\d{4}-\d{2}-\d{2} \d{2}:\d{2})',(.*),(.*)`)
-	writtenRegex = regexp.MustCompile(`^(\d+)\t(\d+)\t`)
-	pathRegex    = regexp.MustCompile(`^\d+\t\d+\t(.*)`)
+	headerRegex = regexp.MustCompile(
+		"^\x1e([0-9a-f]*)\x1f([0-9a-f ]*)\x1f(\\d{4}-\\d{2}-\\d{2} \\d{2}:\\d{2}(?: [+-]\\d{4})?)" +
+			"\x1f([^\x1f]*)\x1f([^\x1f]*)\x1f([^\x1f]*)\x1f([^\x1f]*)\x1f([^\x1f]*)(?:\x1f(.*))?$",
+	)
+	writtenRegex = regexp.MustCompile(`^(\d+|-)\t(\d+|-)\t`)
+	pathRegex    = regexp.MustCompile(`^(?:\d+|-)\t(?:\d+|-)\t(.*)`)
+	renameRegex  = regexp.MustCompile(`^(.*)\{(.*) => (.*)\}(.*)$`)
 )
 
 // Parse parses the logs.
 func Parse(rawLogs []string) ([]*Log, error) {
 	logs := make([]*Log, 0)
+	commit := &Log{}
 	log := &Log{}
 
 	for _, line := range rawLogs {
-		if matches := headerRegex.FindStringSubmatch(line); len(matches) == 4 {
-			date, err := dateutil.ToTime(matches[1])
+		if matches := headerRegex.FindStringSubmatch(line); len(matches) == 10 {
+			date, err := dateutil.ToTime(matches[3])
 			if err != nil {
 				return nil, err
 			}
-			log.Date = timestamppb.New(date)
 
-			name := matches[3]
-			if name == "" {
-				name = "Unknown Name"
-			}
+			_, offset := date.Zone()
 
-			email := matches[2]
-			if email == "" {
-				email = "Unknown Email"
-			}
+			parents := strings.Fields(matches[2])
 
-			author := strings.TrimSpace(fmt.Sprintf("%s (%s)", name, email))
-			log.Author = author
+			commit = &Log{
+				Date:      timestamppb.New(date),
+				TzOffset:  int32(offset / 60),
+				Author:    FormatIdentity(matches[5], matches[4]),
+				Hash:      matches[1],
+				Parents:   parents,
+				Merge:     len(parents) > 1,
+				Subject:   matches[8],
+				Committer: FormatIdentity(matches[7], matches[6]),
+				CoAuthors: parseCoAuthors(matches[9]),
+			}
+			log = newCommitLog(commit)
 
 			continue
 		}
 
 		if matches := writtenRegex.FindStringSubmatch(line); len(matches) == 3 {
+			// Binary files are reported as "-\t-\tpath", as lines do not apply to them.
+			log.Binary = matches[1] == "-" && matches[2] == "-"
+
 			var plus int32
 			if _, err := fmt.Sscanf(matches[1], "%d", &plus); err == nil {
 				log.Plus = plus
@@ -61,7 +73,7 @@ func Parse(rawLogs []string) ([]*Log, error) {
 		}
 
 		if matches := pathRegex.FindStringSubmatch(line); len(matches) == 2 {
-			log.Path = matches[1]
+			log.OldPath, log.Path = resolveRename(unquotePath(matches[1]))
 
 			if log.Plus > 3_000 || log.Minus > 3_000 {
 				logger.Warn(
@@ -74,10 +86,7 @@ func Parse(rawLogs []string) ([]*Log, error) {
 			}
 
 			logs = append(logs, log)
-			log = &Log{
-				Date:   log.Date,
-				Author: log.Author,
-			}
+			log = newCommitLog(commit)
 		}
 
 	}
@@ -86,3 +95,74 @@ func Parse(rawLogs []string) ([]*Log, error) {
 
 	return logs, nil
 }
+
+// resolveRename resolves the rename notation of numstat, either "old => new" or
+// "dir/{old => new}/file", into the old and the new path. The old path is empty when the path
+// was not renamed.
+func resolveRename(path string) (oldPath string, newPath string) {
+	if matches := renameRegex.FindStringSubmatch(path); len(matches) == 5 {
+		oldPath = cleanPath(matches[1] + matches[2] + matches[4])
+		newPath = cleanPath(matches[1] + matches[3] + matches[4])
+		return oldPath, newPath
+	}
+
+	if before, after, found := strings.Cut(path, " => "); found {
+		return before, after
+	}
+
+	return "", path
+}
+
+// cleanPath removes the duplicated slashes left by empty rename parts, like in
+// "{ => dir}/file".
+func cleanPath(path string) string {
+	for strings.Contains(path, "//") {
+		path = strings.ReplaceAll(path, "//", "/")
+	}
+	return strings.TrimPrefix(path, "/")
+}
+
+// unquotePath unquotes the paths git quotes because of unusual characters.
+func unquotePath(path string) string {
+	if len(path) < 2 || !strings.HasPrefix(path, `"`) || !strings.HasSuffix(path, `"`) {
+		return path
+	}
+
+	unquoted, err := strconv.Unquote(path)
+	if err != nil {
+		return path
+	}
+
+	return unquoted
+}
+
+// newCommitLog creates a log carrying the commit information of the given commit.
+func newCommitLog(commit *Log) *Log {
+	return &Log{
+		Date:      commit.Date,
+		TzOffset:  commit.TzOffset,
+		Author:    commit.Author,
+		Hash:      commit.Hash,
+		Parents:   commit.Parents,
+		Subject:   commit.Subject,
+		Committer: commit.Committer,
+		CoAuthors: commit.CoAuthors,
+		Merge:     commit.Merge,
+	}
+}
+
+// parseCoAuthors parses the "Name <email>" values of the Co-authored-by trailers, separated by
+// group separators.
+func parseCoAuthors(trailers string) []string {
+	var coAuthors []string
+	for _, trailer := range strings.Split(trailers, "\x1d") {
+		trailer = strings.TrimSpace(trailer)
+		if trailer == "" {
+			continue
+		}
+
+		coAuthors = append(coAuthors, ParseContact(trailer))
+	}
+
+	return coAuthors
+}
@@ -0,0 +1,123 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	rawLogs := []string{
+		"\x1eb2c4\x1fa1b3 c3d5\x1f2023-09-12 14:15 +0200\x1fjohn@doe.com\x1fJohn\x1fjane@doe.com\x1fJane\x1fMerge branch, with commas\x1fJane Doe <jane@doe.com>\x1dBob",
+		"10\t2\tmain.go",
+		"3\t0\tREADME.md",
+		"",
+		"\x1ea1b3\x1f\x1f2023-09-11 09:00\x1f\x1f\x1f\x1f\x1fInitial commit",
+		"1\t0\tmain.go",
+	}
+
+	logs, err := Parse(rawLogs)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if len(logs) != 3 {
+		t.Fatalf("Parse() got %d logs, want 3", len(logs))
+	}
+
+	got := logs[0]
+	if got.GetHash() != "b2c4" ||
+		!reflect.DeepEqual(got.GetParents(), []string{"a1b3", "c3d5"}) ||
+		!got.GetMerge() ||
+		got.GetSubject() != "Merge branch, with commas" ||
+		got.GetAuthor() != "John (john@doe.com)" ||
+		got.GetCommitter() != "Jane (jane@doe.com)" ||
+		!reflect.DeepEqual(got.GetCoAuthors(), []string{"Jane Doe (jane@doe.com)", "Bob (Unknown Email)"}) ||
+		got.GetPath() != "README.md" ||
+		got.GetPlus() != 3 ||
+		got.GetTzOffset() != 120 ||
+		!got.GetDate().AsTime().Equal(time.Date(2023, 9, 12, 12, 15, 0, 0, time.UTC)) ||
+		got.Time(nil).Hour() != 14 {
+		t.Errorf("Parse() got = %v", got)
+	}
+
+	if logs[1].GetPath() != "main.go" || logs[1].GetDiff() != 8 {
+		t.Errorf("Parse() got = %v", logs[1])
+	}
+
+	root := logs[2]
+	if root.GetHash() != "a1b3" ||
+		len(root.GetParents()) != 0 ||
+		root.GetMerge() ||
+		root.GetAuthor() != "Unknown Name (Unknown Email)" ||
+		len(root.GetCoAuthors()) != 0 ||
+		root.GetSubject() != "Initial commit" {
+		t.Errorf("Parse() got = %v", root)
+	}
+}
+
+func TestParse_BinaryAndRenames(t *testing.T) {
+	rawLogs := []string{
+		"\x1eb2c4\x1fa1b3\x1f2023-09-12 14:15 +0000\x1fjohn@doe.com\x1fJohn\x1fjohn@doe.com\x1fJohn\x1fMove files",
+		"-\t-\tassets/logo.png",
+		"4\t1\tsrc/{old => new}/file.go",
+		"0\t0\tdocs.md => README.md",
+	}
+
+	logs, err := Parse(rawLogs)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	expected := []*Log{
+		{Path: "README.md", OldPath: "docs.md"},
+		{Path: "assets/logo.png", Binary: true},
+		{Path: "src/new/file.go", OldPath: "src/old/file.go", Plus: 4, Minus: 1, Diff: 3},
+	}
+
+	if len(logs) != len(expected) {
+		t.Fatalf("Parse() got %d logs, want %d", len(logs), len(expected))
+	}
+
+	for i, want := range expected {
+		got := logs[i]
+		if got.GetPath() != want.GetPath() ||
+			got.GetOldPath() != want.GetOldPath() ||
+			got.GetBinary() != want.GetBinary() ||
+			got.GetPlus() != want.GetPlus() ||
+			got.GetMinus() != want.GetMinus() ||
+			got.GetDiff() != want.GetDiff() {
+			t.Errorf("Parse() got = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestResolveRename(t *testing.T) {
+	tests := []struct {
+		path    string
+		oldPath string
+		newPath string
+	}{
+		{"main.go", "", "main.go"},
+		{"old.go => new.go", "old.go", "new.go"},
+		{"src/{old => new}/file.go", "src/old/file.go", "src/new/file.go"},
+		{"src/{ => lib}/file.go", "src/file.go", "src/lib/file.go"},
+		{"{lib => }/file.go", "lib/file.go", "file.go"},
+		{"src/{a.go => b.go}", "src/a.go", "src/b.go"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			oldPath, newPath := resolveRename(tt.path)
+			if oldPath != tt.oldPath || newPath != tt.newPath {
+				t.Errorf(
+					"resolveRename() got = (%s, %s), want (%s, %s)",
+					oldPath,
+					newPath,
+					tt.oldPath,
+					tt.newPath,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package data
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ProjectUnassigned is the project of the logs whose path is not mapped to any project.
+const ProjectUnassigned = "unassigned"
+
+// projectPattern holds a path pattern of a project and the regex it is compiled to.
+type projectPattern struct {
+	project string
+	pattern string
+	regex   *regexp.Regexp
+}
+
+// projectResolver resolves paths to the projects they belong to.
+type projectResolver []projectPattern
+
+// newProjectResolver compiles the path patterns of each project. The patterns are globs where
+// "*" matches within a directory and "**" across directories, and a pattern without wildcards
+// matches the path itself and everything below it. The longest pattern matching a path wins,
+// so nested projects can be mapped.
+func newProjectResolver(projects map[string][]string) (projectResolver, error) {
+	var resolver projectResolver
+	for project, patterns := range projects {
+		for _, pattern := range patterns {
+			regex, err := compileProjectPattern(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("Project pattern expected to be a valid glob: %s.", pattern)
+			}
+			resolver = append(resolver, projectPattern{project: project, pattern: pattern, regex: regex})
+		}
+	}
+
+	sort.Slice(resolver, func(i, j int) bool {
+		if len(resolver[i].pattern) != len(resolver[j].pattern) {
+			return len(resolver[i].pattern) > len(resolver[j].pattern)
+		}
+		return resolver[i].project < resolver[j].project
+	})
+
+	return resolver, nil
+}
+
+// resolve returns the project of the path, which is empty when no pattern matches.
+func (r projectResolver) resolve(path string) string {
+	for _, p := range r {
+		if p.regex.MatchString(path) {
+			return p.project
+		}
+	}
+	return ""
+}
+
+// compileProjectPattern compiles a glob pattern of a project into a regex matching the paths.
+func compileProjectPattern(pattern string) (*regexp.Regexp, error) {
+	pattern = strings.Trim(pattern, "/")
+	if pattern == "" {
+		return nil, fmt.Errorf("Empty pattern")
+	}
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case c == '*':
+			expr.WriteString("[^/]*")
+		case c == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	if !strings.ContainsAny(pattern, "*?") {
+		expr.WriteString("(?:/.*)?")
+	}
+	expr.WriteString("$")
+
+	return regexp.Compile(expr.String())
+}
+
+// WithProjectMapping sets the project of the logs whose path matches the patterns of a
+// project. The logs matching no pattern keep their project.
+func WithProjectMapping(projects map[string][]string) FilterOption {
+	return func(logs []*Log) ([]*Log, error) {
+		if len(projects) == 0 {
+			return logs, nil
+		}
+
+		resolver, err := newProjectResolver(projects)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, log := range logs {
+			if project := resolver.resolve(log.GetPath()); project != "" {
+				log.Project = project
+			}
+		}
+
+		return logs, nil
+	}
+}
+
+// ResolveProjects returns the project of each of the paths, in the same order. Paths matching no
+// pattern belong to ProjectUnassigned.
+func ResolveProjects(projects map[string][]string, paths []string) ([]string, error) {
+	resolver, err := newProjectResolver(projects)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make([]string, 0, len(paths))
+	for _, path := range paths {
+		project := resolver.resolve(path)
+		if project == "" {
+			project = ProjectUnassigned
+		}
+		resolved = append(resolved, project)
+	}
+
+	return resolved, nil
+}
+
+// WithProjects filters logs by project. A log is kept when its project is any of the included
+// projects, or all of them when none is included, and none of the excluded projects. Logs
+// without a project belong to ProjectUnassigned.
+func WithProjects(include, exclude []string) FilterOption {
+	return func(logs []*Log) ([]*Log, error) {
+		if len(include) == 0 && len(exclude) == 0 {
+			return logs, nil
+		}
+
+		var filteredLogs []*Log
+		for _, log := range logs {
+			project := log.ProjectName()
+			if len(include) > 0 && !containsString(include, project) {
+				continue
+			}
+
+			if containsString(exclude, project) {
+				continue
+			}
+
+			filteredLogs = append(filteredLogs, log)
+		}
+
+		if len(filteredLogs) == 0 {
+			return nil, noLogsErrorf("No logs found for the selected projects.")
+		}
+
+		return filteredLogs, nil
+	}
+}
@@ -0,0 +1,97 @@
+package data
+
+import (
+	"testing"
+)
+
+func TestFilter_WithProjectMapping(t *testing.T) {
+	projects := map[string][]string{
+		"billing":  {"services/billing"},
+		"auth":     {"services/auth/**"},
+		"payments": {"services/billing/payments/*.go"},
+		"docs":     {"**/*.md"},
+	}
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{path: "services/billing/invoice.go", expected: "billing"},
+		{path: "services/billing", expected: "billing"},
+		{path: "services/billing-v2/invoice.go", expected: "kept"},
+		{path: "services/auth/internal/token.go", expected: "auth"},
+		{path: "services/billing/payments/card.go", expected: "payments"},
+		{path: "services/billing/payments/card/visa.go", expected: "billing"},
+		{path: "README.md", expected: "docs"},
+		{path: "main.go", expected: "kept"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			logs := &Logs{Logs: []*Log{{Path: tt.path, Project: "kept"}}}
+
+			got, err := Filter(logs, WithProjectMapping(projects))
+			if err != nil {
+				t.Fatalf("Filter() error = %v", err)
+			}
+
+			if project := got.Logs[0].GetProject(); project != tt.expected {
+				t.Errorf("Filter() project = %q, expected %q", project, tt.expected)
+			}
+		})
+	}
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := Filter(&Logs{Logs: []*Log{{Path: "main.go"}}}, WithProjectMapping(map[string][]string{"root": {"/"}}))
+		if err == nil {
+			t.Error("Filter() expected an error")
+		}
+	})
+}
+
+func TestFilter_WithProjects(t *testing.T) {
+	newLogs := func() *Logs {
+		return &Logs{
+			Logs: []*Log{
+				{Path: "services/billing/invoice.go", Project: "billing"},
+				{Path: "services/auth/token.go", Project: "auth"},
+				{Path: "main.go"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		expected []string
+		wantErr  bool
+	}{
+		{name: "no projects", expected: []string{"services/billing/invoice.go", "services/auth/token.go", "main.go"}},
+		{name: "include", include: []string{"auth"}, expected: []string{"services/auth/token.go"}},
+		{name: "include unassigned", include: []string{ProjectUnassigned}, expected: []string{"main.go"}},
+		{name: "exclude", exclude: []string{"billing", ProjectUnassigned}, expected: []string{"services/auth/token.go"}},
+		{name: "no logs", include: []string{"payments"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Filter(newLogs(), WithProjects(tt.include, tt.exclude))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Filter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(got.Logs) != len(tt.expected) {
+				t.Fatalf("Filter() returned %d logs, expected %d", len(got.Logs), len(tt.expected))
+			}
+			for i, log := range got.Logs {
+				if log.GetPath() != tt.expected[i] {
+					t.Errorf("Filter() log %d = %q, expected %q", i, log.GetPath(), tt.expected[i])
+				}
+			}
+		})
+	}
+}
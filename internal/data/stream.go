@@ -0,0 +1,330 @@
+package data
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// streamMagic starts the reports in the streaming format, telling them apart from the reports
+// holding a single Report message, which never start with these bytes.
+const streamMagic = "produgit-stream\n"
+
+// gzipMagic starts the reports compressed with gzip.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// StreamWriter writes a report in the streaming format: the magic followed by length-delimited
+// records holding the metadata, then a chunk per repository followed by its logs, then the
+// checkpoints.
+type StreamWriter struct {
+	w   *bufio.Writer
+	gz  *gzip.Writer
+	buf []byte
+}
+
+// NewStreamWriter creates a StreamWriter writing the metadata to w, compressing the report with
+// gzip when compress is true.
+func NewStreamWriter(w io.Writer, compress bool, metadata *Metadata) (*StreamWriter, error) {
+	s := &StreamWriter{}
+	if compress {
+		s.gz = gzip.NewWriter(w)
+		w = s.gz
+	}
+	s.w = bufio.NewWriter(w)
+
+	if _, err := s.w.WriteString(streamMagic); err != nil {
+		return nil, err
+	}
+
+	if metadata == nil {
+		metadata = &Metadata{}
+	}
+
+	if err := s.writeRecord(&Record{Metadata: metadata}); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// WriteChunk writes the logs of a repository.
+func (s *StreamWriter) WriteChunk(repository string, logs []*Log) error {
+	chunk := &Chunk{Repository: repository, Count: uint32(len(logs))}
+	if err := s.writeRecord(&Record{Chunk: chunk}); err != nil {
+		return err
+	}
+
+	for _, log := range logs {
+		if err := s.writeRecord(&Record{Log: log}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteCheckpoint writes a checkpoint.
+func (s *StreamWriter) WriteCheckpoint(checkpoint *Checkpoint) error {
+	return s.writeRecord(&Record{Checkpoint: checkpoint})
+}
+
+// Close flushes the records written. It does not close the underlying writer.
+func (s *StreamWriter) Close() error {
+	if err := s.w.Flush(); err != nil {
+		return err
+	}
+
+	if s.gz != nil {
+		return s.gz.Close()
+	}
+
+	return nil
+}
+
+// writeRecord writes the record prefixed by its length.
+func (s *StreamWriter) writeRecord(record *Record) error {
+	content, err := proto.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("Marshaling record failed: %w", err)
+	}
+
+	s.buf = binary.AppendUvarint(s.buf[:0], uint64(len(content)))
+	if _, err := s.w.Write(s.buf); err != nil {
+		return err
+	}
+
+	_, err = s.w.Write(content)
+	return err
+}
+
+// WriteStream writes the report in the streaming format, with a chunk for the logs of each
+// repository in the order they first appear.
+func WriteStream(w io.Writer, report *Report, compress bool) error {
+	stream, err := NewStreamWriter(w, compress, report.GetMetadata())
+	if err != nil {
+		return err
+	}
+
+	var repositories []string
+	chunks := make(map[string][]*Log)
+	for _, log := range report.GetLogs() {
+		if _, ok := chunks[log.GetRepository()]; !ok {
+			repositories = append(repositories, log.GetRepository())
+		}
+		chunks[log.GetRepository()] = append(chunks[log.GetRepository()], log)
+	}
+
+	for _, repository := range repositories {
+		if err := stream.WriteChunk(repository, chunks[repository]); err != nil {
+			return err
+		}
+	}
+
+	for _, checkpoint := range report.GetCheckpoints() {
+		if err := stream.WriteCheckpoint(checkpoint); err != nil {
+			return err
+		}
+	}
+
+	return stream.Close()
+}
+
+// ReportReader reads a report log by log, so the logs can be processed without holding all of
+// them in memory. Reports holding a single Report message, written before the streaming format,
+// are read at once as a single chunk.
+type ReportReader struct {
+	closers     []io.Closer
+	records     *bufio.Reader
+	metadata    *Metadata
+	chunk       *Chunk
+	log         *Log
+	remaining   []*Log
+	checkpoints []*Checkpoint
+	err         error
+}
+
+// OpenReport opens the report at the given path for reading.
+func OpenReport(reportPath string) (*ReportReader, error) {
+	file, err := os.Open(reportPath)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := NewReportReader(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	reader.closers = append(reader.closers, file)
+
+	return reader, nil
+}
+
+// NewReportReader creates a ReportReader reading the metadata of the report from r, migrating
+// it to the current schema version.
+func NewReportReader(r io.Reader) (*ReportReader, error) {
+	reader := &ReportReader{}
+	records := bufio.NewReader(r)
+
+	if head, _ := records.Peek(len(gzipMagic)); bytes.Equal(head, gzipMagic) {
+		gz, err := gzip.NewReader(records)
+		if err != nil {
+			return nil, fmt.Errorf("Decompressing report failed: %w", err)
+		}
+		reader.closers = append(reader.closers, gz)
+		records = bufio.NewReader(gz)
+	}
+
+	if head, _ := records.Peek(len(streamMagic)); string(head) != streamMagic {
+		return reader, reader.readMessage(records)
+	}
+
+	if _, err := records.Discard(len(streamMagic)); err != nil {
+		return nil, err
+	}
+	reader.records = records
+
+	record, err := reader.readRecord()
+	if err != nil {
+		return nil, err
+	}
+
+	if record.GetMetadata() == nil {
+		return nil, fmt.Errorf("Report does not start with its metadata.")
+	}
+	reader.metadata = record.GetMetadata()
+
+	// Streams are written with the current schema, so migrating them only involves the
+	// metadata until the schema of the logs changes.
+	if err := Migrate(&Report{Metadata: reader.metadata}); err != nil {
+		return nil, err
+	}
+
+	return reader, nil
+}
+
+// readMessage reads a report holding a single Report message.
+func (r *ReportReader) readMessage(records io.Reader) error {
+	content, err := io.ReadAll(records)
+	if err != nil {
+		return err
+	}
+
+	report := &Report{}
+	if err := proto.Unmarshal(content, report); err != nil {
+		return err
+	}
+
+	if err := Migrate(report); err != nil {
+		return err
+	}
+
+	r.metadata = report.GetMetadata()
+	r.chunk = &Chunk{Count: uint32(len(report.GetLogs()))}
+	r.remaining = report.GetLogs()
+	r.checkpoints = report.GetCheckpoints()
+
+	return nil
+}
+
+// readRecord reads the next record, which is nil at the end of the report.
+func (r *ReportReader) readRecord() (*Record, error) {
+	length, err := binary.ReadUvarint(r.records)
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Report is truncated: %w", err)
+	}
+
+	content := make([]byte, length)
+	if _, err := io.ReadFull(r.records, content); err != nil {
+		return nil, fmt.Errorf("Report is truncated: %w", err)
+	}
+
+	record := &Record{}
+	if err := proto.Unmarshal(content, record); err != nil {
+		return nil, fmt.Errorf("Unmarshaling record failed: %w", err)
+	}
+
+	return record, nil
+}
+
+// Next advances to the next log, returning false at the end of the report or when reading it
+// failed, which is reported by Err.
+func (r *ReportReader) Next() bool {
+	r.log = nil
+
+	if r.records == nil {
+		if len(r.remaining) == 0 {
+			return false
+		}
+
+		r.log, r.remaining = r.remaining[0], r.remaining[1:]
+		return true
+	}
+
+	for {
+		record, err := r.readRecord()
+		if err != nil {
+			r.err = err
+			return false
+		}
+
+		switch {
+		case record == nil:
+			return false
+		case record.GetLog() != nil:
+			r.log = record.GetLog()
+			return true
+		case record.GetChunk() != nil:
+			r.chunk = record.GetChunk()
+		case record.GetCheckpoint() != nil:
+			r.checkpoints = append(r.checkpoints, record.GetCheckpoint())
+		}
+	}
+}
+
+// Log returns the current log.
+func (r *ReportReader) Log() *Log {
+	return r.log
+}
+
+// Chunk returns the chunk of the current log.
+func (r *ReportReader) Chunk() *Chunk {
+	return r.chunk
+}
+
+// Metadata returns the metadata of the report.
+func (r *ReportReader) Metadata() *Metadata {
+	return r.metadata
+}
+
+// Checkpoints returns the checkpoints of the report, which are complete once Next returns
+// false.
+func (r *ReportReader) Checkpoints() []*Checkpoint {
+	return r.checkpoints
+}
+
+// Err returns the error that stopped Next, if any.
+func (r *ReportReader) Err() error {
+	return r.err
+}
+
+// Close closes the report.
+func (r *ReportReader) Close() error {
+	var err error
+	for _, closer := range r.closers {
+		if closeErr := closer.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
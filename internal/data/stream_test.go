@@ -0,0 +1,102 @@
+package data
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteStream(t *testing.T) {
+	report := &Report{
+		Logs: []*Log{
+			{Repository: "/work/billing", Hash: "a1", Author: "John"},
+			{Repository: "/work/auth", Hash: "b2", Author: "Jane"},
+			{Repository: "/work/billing", Hash: "c3", Author: "Jane"},
+		},
+		Checkpoints: []*Checkpoint{{Repository: "/work/billing", Hash: "c3"}},
+		Metadata:    &Metadata{SchemaVersion: SchemaVersion, MergePolicy: "exclude"},
+	}
+
+	for _, compress := range []bool{false, true} {
+		var buf bytes.Buffer
+		if err := WriteStream(&buf, report, compress); err != nil {
+			t.Fatalf("WriteStream() error = %v", err)
+		}
+
+		reader, err := NewReportReader(&buf)
+		if err != nil {
+			t.Fatalf("NewReportReader() error = %v", err)
+		}
+
+		if reader.Metadata().GetMergePolicy() != "exclude" {
+			t.Errorf("Metadata() got = %v", reader.Metadata())
+		}
+
+		var hashes []string
+		var repositories []string
+		for reader.Next() {
+			hashes = append(hashes, reader.Log().GetHash())
+			repositories = append(repositories, reader.Chunk().GetRepository())
+		}
+
+		if reader.Err() != nil {
+			t.Fatalf("Err() = %v", reader.Err())
+		}
+
+		// The logs are grouped in a chunk per repository.
+		expectedHashes := []string{"a1", "c3", "b2"}
+		expectedRepositories := []string{"/work/billing", "/work/billing", "/work/auth"}
+		for i := range expectedHashes {
+			if i >= len(hashes) ||
+				hashes[i] != expectedHashes[i] ||
+				repositories[i] != expectedRepositories[i] {
+				t.Fatalf("Next() got = %v in %v, compress = %t", hashes, repositories, compress)
+			}
+		}
+
+		if len(reader.Checkpoints()) != 1 {
+			t.Errorf("Checkpoints() got = %v", reader.Checkpoints())
+		}
+	}
+}
+
+func TestLoadFiltered(t *testing.T) {
+	report := &Report{
+		Logs: []*Log{
+			{Repository: "/work/billing", Author: "John"},
+			{Repository: "/work/auth", Author: "Jane"},
+			{Repository: "/work/auth", Author: "John"},
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "report.pb")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := WriteStream(file, report, true); err != nil {
+		t.Fatalf("WriteStream() error = %v", err)
+	}
+	file.Close()
+
+	logs, err := LoadFiltered(path, WithAuthors([]string{"Jane"}))
+	if err != nil {
+		t.Fatalf("LoadFiltered() error = %v", err)
+	}
+
+	if len(logs.GetLogs()) != 1 || logs.GetLogs()[0].GetRepository() != "/work/auth" {
+		t.Errorf("LoadFiltered() got = %v", logs)
+	}
+
+	_, err = LoadFiltered(path, WithAuthors([]string{"Bob"}))
+	if !errors.Is(err, ErrNoLogs) {
+		t.Errorf("LoadFiltered() error = %v, want ErrNoLogs", err)
+	}
+
+	logs, err = Load(path)
+	if err != nil || len(logs.GetLogs()) != 3 {
+		t.Errorf("Load() got = %v, err = %v", logs, err)
+	}
+}
@@ -1,42 +1,636 @@
 package git
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os/exec"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	cmdutil "github.com/christian-gama/produgit/internal/util/cmd"
 )
 
+// LogFormat is the pretty format used by GetLog. Each commit starts with a record separator
+// followed by the hash, the parent hashes, the author date, the author email and name, the
+// committer email and name, the subject and the Co-authored-by trailers, separated by unit
+// separators. The trailers are separated by group separators. Names and emails respect the
+// .mailmap of the repository.
+const LogFormat = "%x1e%H%x1f%P%x1f%ad%x1f%aE%x1f%aN%x1f%cE%x1f%cN%x1f%s" +
+	"%x1f%(trailers:key=Co-authored-by,valueonly,separator=%x1d)"
+
+const (
+	// MergesDefault keeps the default behaviour of git log, which lists merge commits without
+	// their changes.
+	MergesDefault = "default"
+
+	// MergesExclude excludes the merge commits.
+	MergesExclude = "exclude"
+
+	// MergesFirstParent follows only the first parent of merge commits, attributing the changes
+	// they bring to the mainline to the merge commit itself.
+	MergesFirstParent = "first-parent"
+
+	// MergesSeparate includes the changes of merge commits against their first parent along with
+	// the commits of every branch, so merges can be told apart and filtered out later. The lines
+	// of a merged branch are counted in its commits and again in the merge commit, so the
+	// merges are filtered out by default when reading such reports.
+	MergesSeparate = "separate"
+)
+
+// MergePolicies returns the accepted policies for merge commits.
+func MergePolicies() []string {
+	return []string{MergesDefault, MergesExclude, MergesFirstParent, MergesSeparate}
+}
+
+// LogOptions holds the options used by GetLog.
+type LogOptions struct {
+	// Exclude holds the pathspecs to be excluded from the log.
+	Exclude []string
+
+	// Revisions limits the log to the given revisions or ranges, defaults to HEAD.
+	Revisions []string
+
+	// Merges is the policy for merge commits, defaults to MergesDefault.
+	Merges string
+
+	// Since limits the log to the commits committed at or after it, unless zero.
+	Since time.Time
+
+	// Until limits the log to the commits committed at or before it, unless zero.
+	Until time.Time
+}
+
+// RefSelection selects the refs whose history is logged instead of HEAD.
+type RefSelection struct {
+	// All selects every ref, like git log --all.
+	All bool
+
+	// Branches selects every local branch.
+	Branches bool
+
+	// Branch holds the names or globs of the local branches selected, e.g. "feat/*".
+	Branch []string
+
+	// Remotes selects every remote-tracking branch.
+	Remotes bool
+
+	// Tags selects every tag.
+	Tags bool
+}
+
+// Patterns returns the patterns matching the selected refs, as accepted by git for-each-ref. It
+// is empty when no ref is selected.
+func (s *RefSelection) Patterns() []string {
+	if s == nil {
+		return nil
+	}
+
+	if s.All {
+		return []string{"refs"}
+	}
+
+	var patterns []string
+	if s.Branches {
+		patterns = append(patterns, "refs/heads")
+	}
+	for _, branch := range s.Branch {
+		patterns = append(patterns, "refs/heads/"+branch)
+	}
+	if s.Remotes {
+		patterns = append(patterns, "refs/remotes")
+	}
+	if s.Tags {
+		patterns = append(patterns, "refs/tags")
+	}
+
+	return patterns
+}
+
+// Ref is a ref along with the commit it points to.
+type Ref struct {
+	Name string
+	Hash string
+}
+
 // GetLog returns the git log for the given repoPath
-func GetLog(repoPath string, exclude []string) ([]string, error) {
+func GetLog(ctx context.Context, repoPath string, opts *LogOptions) ([]string, error) {
+	return runLog(
+		ctx,
+		repoPath,
+		opts,
+		"--pretty=format:"+LogFormat,
+		"--date=format:%Y-%m-%d %H:%M %z",
+		"--numstat",
+	)
+}
+
+// PatchFormat is the pretty format used by StreamPatch. Each commit starts with a record
+// separator followed by the hash.
+const PatchFormat = "%x1e%H"
+
+// StreamPatch calls handle with each line of the patches of the commits GetLog returns for the
+// given repoPath, as git outputs them, so the patches of the whole history are never held in
+// memory. The changes of whitespace and blank lines are left out of the patches.
+func StreamPatch(ctx context.Context, repoPath string, opts *LogOptions, handle func(line string)) error {
+	if err := checkGitExists(); err != nil {
+		return err
+	}
+
+	args, err := logArgs(
+		repoPath,
+		opts,
+		"--pretty=format:"+PatchFormat,
+		"--patch",
+		"--ignore-all-space",
+		"--ignore-blank-lines",
+		"--no-color",
+		"--no-ext-diff",
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cmdutil.StreamContext(ctx, handle, "git", args...)
+	if err != nil && !strings.Contains(err.Error(), "does not have any commits yet") {
+		return fmt.Errorf("Could not run git log: %w", err)
+	}
+
+	return nil
+}
+
+// runLog runs git log for the given repoPath with the format arguments and the options.
+func runLog(ctx context.Context, repoPath string, opts *LogOptions, formatArgs ...string) ([]string, error) {
 	if err := checkGitExists(); err != nil {
 		return nil, err
 	}
 
+	args, err := logArgs(repoPath, opts, formatArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := cmdutil.RunAndWaitContext(ctx, "git", args...)
+	if err != nil && !strings.Contains(err.Error(), "does not have any commits yet") {
+		return nil, fmt.Errorf("Could not run git log: %w", err)
+	}
+
+	return strings.Split(output, "\n"), nil
+}
+
+// logArgs returns the arguments of git log for the given repoPath with the format arguments and
+// the options.
+func logArgs(repoPath string, opts *LogOptions, formatArgs ...string) ([]string, error) {
 	absRepoPath, err := filepath.Abs(repoPath)
 	if err != nil {
 		return nil, fmt.Errorf("Could not convert to absolute path: %s", err)
 	}
 
-	args := []string{
-		"-C", absRepoPath, "log",
-		"--pretty=format:%ad,%ae,%an",
-		"--date=format:'%Y-%m-%d %H:%M'",
-		"--numstat",
-		"--",
-		".",
+	args := append([]string{"-C", absRepoPath, "log"}, formatArgs...)
+	args = append(args, "--find-renames")
+
+	if opts.Merges == MergesFirstParent || opts.Merges == MergesSeparate {
+		args = append(args, "--diff-merges=first-parent")
 	}
 
-	args = appendExcludeArgs(args, exclude)
+	historyArgs, err := historyArgs(opts)
+	if err != nil {
+		return nil, err
+	}
 
-	output, err := cmdutil.RunAndWait("git", args...)
-	if err != nil && !strings.Contains(err.Error(), "does not have any commits yet") {
-		return nil, fmt.Errorf("Could not run git log: %s", err)
+	return append(args, historyArgs...), nil
+}
+
+// historyArgs returns the arguments selecting the commits and files of the history described by
+// the options, shared by git log and git rev-list.
+func historyArgs(opts *LogOptions) ([]string, error) {
+	var args []string
+
+	switch opts.Merges {
+	case "", MergesDefault, MergesSeparate:
+	case MergesExclude:
+		args = append(args, "--no-merges")
+	case MergesFirstParent:
+		args = append(args, "--first-parent")
+	default:
+		return nil, fmt.Errorf("Merge policy must be one of %v", MergePolicies())
 	}
 
-	return strings.Split(output, "\n"), nil
+	if !opts.Since.IsZero() {
+		args = append(args, "--since="+opts.Since.Format(time.RFC3339))
+	}
+	if !opts.Until.IsZero() {
+		args = append(args, "--until="+opts.Until.Format(time.RFC3339))
+	}
+
+	args = append(args, opts.Revisions...)
+	args = append(args, "--", ".")
+	args = appendExcludeArgs(args, opts.Exclude)
+
+	return args, nil
+}
+
+// Head returns the commit hash HEAD points to and the ref HEAD is attached to. The ref is
+// "HEAD" when the repository is in a detached state and the hash is empty when the repository
+// does not have any commits yet.
+func Head(ctx context.Context, repoPath string) (hash string, ref string, err error) {
+	if err := checkGitExists(); err != nil {
+		return "", "", err
+	}
+
+	ref, err = cmdutil.RunAndWaitContext(ctx, "git", "-C", repoPath, "symbolic-ref", "-q", "HEAD")
+	if ctx.Err() != nil {
+		return "", "", ctx.Err()
+	}
+	if err != nil {
+		ref = "HEAD"
+	}
+
+	hash, err = cmdutil.RunAndWaitContext(ctx, "git", "-C", repoPath, "rev-parse", "-q", "--verify", "HEAD^{commit}")
+	if ctx.Err() != nil {
+		return "", "", ctx.Err()
+	}
+	if err != nil {
+		hash = ""
+	}
+
+	return strings.TrimSpace(hash), strings.TrimSpace(ref), nil
+}
+
+// IsAncestor reports whether the commit ancestor is reachable from the commit descendant. A
+// commit that no longer exists is not an ancestor of anything.
+func IsAncestor(ctx context.Context, repoPath, ancestor, descendant string) (bool, error) {
+	if err := checkGitExists(); err != nil {
+		return false, err
+	}
+
+	_, err := cmdutil.RunAndWaitContext(
+		ctx,
+		"git", "-C", repoPath, "merge-base", "--is-ancestor", ancestor, descendant,
+	)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return false, nil
+		}
+		return false, fmt.Errorf("Could not run git merge-base: %w", err)
+	}
+
+	return true, nil
+}
+
+// HasCommit reports whether the commit exists in the repository, which is not the case once it
+// was garbage collected after a rewrite.
+func HasCommit(ctx context.Context, repoPath, hash string) (bool, error) {
+	if err := checkGitExists(); err != nil {
+		return false, err
+	}
+
+	_, err := cmdutil.RunAndWaitContext(ctx, "git", "-C", repoPath, "cat-file", "-e", hash+"^{commit}")
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return false, nil
+		}
+		return false, fmt.Errorf("Could not run git cat-file: %w", err)
+	}
+
+	return true, nil
+}
+
+// ListRefs returns the refs matching the patterns, as matched by git for-each-ref, sorted by
+// name. Annotated tags are resolved to the commit they tag and refs to other objects are left
+// out.
+func ListRefs(ctx context.Context, repoPath string, patterns []string) ([]Ref, error) {
+	if err := checkGitExists(); err != nil {
+		return nil, err
+	}
+
+	args := append(
+		[]string{
+			"-C", repoPath, "for-each-ref",
+			"--format=%(refname)%00%(objecttype)%00%(objectname)%00%(*objecttype)%00%(*objectname)",
+		},
+		patterns...,
+	)
+	output, err := cmdutil.RunAndWaitContext(ctx, "git", args...)
+	if err != nil {
+		return nil, fmt.Errorf("Could not run git for-each-ref: %w", err)
+	}
+
+	var refs []Ref
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 5 {
+			continue
+		}
+
+		switch {
+		case fields[1] == "commit":
+			refs = append(refs, Ref{Name: fields[0], Hash: fields[2]})
+		case fields[3] == "commit":
+			refs = append(refs, Ref{Name: fields[0], Hash: fields[4]})
+		}
+	}
+
+	return refs, nil
+}
+
+// RootCommits returns the sorted hashes of the commits without parents reachable from HEAD.
+// Repositories sharing a root commit share history, like clones and forks of the same project.
+func RootCommits(ctx context.Context, repoPath string) ([]string, error) {
+	if err := checkGitExists(); err != nil {
+		return nil, err
+	}
+
+	output, err := cmdutil.RunAndWaitContext(ctx, "git", "-C", repoPath, "rev-list", "--max-parents=0", "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("Could not run git rev-list: %w", err)
+	}
+
+	roots := strings.Fields(output)
+	sort.Strings(roots)
+
+	return roots, nil
+}
+
+// RemoteURL returns the normalized URL of the origin remote of the given repoPath, falling back
+// to the first remote. It is empty when the repository does not have any remote.
+func RemoteURL(ctx context.Context, repoPath string) (string, error) {
+	if err := checkGitExists(); err != nil {
+		return "", err
+	}
+
+	remotes, err := cmdutil.RunAndWaitContext(ctx, "git", "-C", repoPath, "remote")
+	if err != nil {
+		return "", fmt.Errorf("Could not run git remote: %w", err)
+	}
+
+	names := strings.Fields(remotes)
+	if len(names) == 0 {
+		return "", nil
+	}
+
+	name := names[0]
+	for _, n := range names {
+		if n == "origin" {
+			name = n
+			break
+		}
+	}
+
+	url, err := cmdutil.RunAndWaitContext(ctx, "git", "-C", repoPath, "remote", "get-url", name)
+	if err != nil {
+		return "", fmt.Errorf("Could not run git remote get-url: %w", err)
+	}
+
+	return NormalizeRemoteURL(url), nil
+}
+
+// NormalizeRemoteURL normalizes a remote URL so the different ways of referring to the same
+// repository are equal, e.g. "git@github.com:foo/bar.git" and "https://github.com/foo/bar"
+// are both normalized to "github.com/foo/bar".
+func NormalizeRemoteURL(url string) string {
+	url = strings.TrimSpace(url)
+
+	if i := strings.Index(url, "://"); i >= 0 {
+		url = url[i+3:]
+	} else if i := strings.Index(url, ":"); i >= 0 && !strings.Contains(url[:i], "/") {
+		url = url[:i] + "/" + url[i+1:]
+	}
+
+	if i := strings.Index(url, "@"); i >= 0 && i < strings.Index(url+"/", "/") {
+		url = url[i+1:]
+	}
+
+	url = strings.TrimSuffix(strings.TrimSuffix(url, "/"), ".git")
+
+	host, path, _ := strings.Cut(url, "/")
+	if path == "" {
+		return strings.ToLower(host)
+	}
+
+	return strings.ToLower(host) + "/" + path
+}
+
+// checkMailmapBatch is the number of contacts given to each git check-mailmap, keeping the
+// command line within the limits of the system.
+const checkMailmapBatch = 500
+
+// CheckMailmap returns the canonical "Name <email>" contacts of the given contacts according to
+// the .mailmap of the repository, in the same order.
+func CheckMailmap(ctx context.Context, repoPath string, contacts []string) ([]string, error) {
+	if err := checkGitExists(); err != nil {
+		return nil, err
+	}
+
+	if len(contacts) == 0 {
+		return nil, nil
+	}
+
+	mapped := make([]string, 0, len(contacts))
+	for start := 0; start < len(contacts); start += checkMailmapBatch {
+		end := start + checkMailmapBatch
+		if end > len(contacts) {
+			end = len(contacts)
+		}
+
+		args := append([]string{"-C", repoPath, "check-mailmap"}, contacts[start:end]...)
+		output, err := cmdutil.RunAndWaitContext(ctx, "git", args...)
+		if err != nil {
+			return nil, fmt.Errorf("Could not run git check-mailmap: %w", err)
+		}
+
+		mapped = append(mapped, strings.Split(strings.TrimSuffix(output, "\n"), "\n")...)
+	}
+
+	return mapped, nil
+}
+
+// CommitCount returns the number of commits git log lists with the given options, which is zero
+// when the repository does not have any commits yet. The commits reachable from HEAD are counted
+// when no revisions are given.
+func CommitCount(ctx context.Context, repoPath string, opts *LogOptions) (int, error) {
+	if err := checkGitExists(); err != nil {
+		return 0, err
+	}
+
+	if len(opts.Revisions) == 0 {
+		hash, _, err := Head(ctx, repoPath)
+		if err != nil || hash == "" {
+			return 0, err
+		}
+		head := *opts
+		head.Revisions = []string{hash}
+		opts = &head
+	}
+
+	historyArgs, err := historyArgs(opts)
+	if err != nil {
+		return 0, err
+	}
+
+	args := append([]string{"-C", repoPath, "rev-list", "--count"}, historyArgs...)
+	output, err := cmdutil.RunAndWaitContext(ctx, "git", args...)
+	if err != nil {
+		return 0, fmt.Errorf("Could not run git rev-list: %w", err)
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(output))
+	if err != nil {
+		return 0, fmt.Errorf("Invalid commit count: %s", output)
+	}
+
+	return count, nil
+}
+
+// IsBare reports whether the repository is a bare repository, without a working tree.
+func IsBare(ctx context.Context, repoPath string) (bool, error) {
+	if err := checkGitExists(); err != nil {
+		return false, err
+	}
+
+	output, err := cmdutil.RunAndWaitContext(ctx, "git", "-C", repoPath, "rev-parse", "--is-bare-repository")
+	if err != nil {
+		return false, fmt.Errorf("Could not run git rev-parse: %w", err)
+	}
+
+	return strings.TrimSpace(output) == "true", nil
+}
+
+// ObjectsSize returns the size in bytes of the objects of the repository, loose and packed,
+// which estimates how much history git log has to go through.
+func ObjectsSize(ctx context.Context, repoPath string) (int64, error) {
+	if err := checkGitExists(); err != nil {
+		return 0, err
+	}
+
+	output, err := cmdutil.RunAndWaitContext(ctx, "git", "-C", repoPath, "count-objects", "-v")
+	if err != nil {
+		return 0, fmt.Errorf("Could not run git count-objects: %w", err)
+	}
+
+	var size int64
+	for _, line := range strings.Split(output, "\n") {
+		key, value, found := strings.Cut(line, ": ")
+		if !found || (key != "size" && key != "size-pack") {
+			continue
+		}
+
+		kib, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("Invalid object size: %s", line)
+		}
+		size += kib * 1024
+	}
+
+	return size, nil
+}
+
+// MatchingFiles returns the tracked files of the repository matching the exclude pattern, as
+// it is matched by GetLog.
+func MatchingFiles(ctx context.Context, repoPath string, pattern string) ([]string, error) {
+	if err := checkGitExists(); err != nil {
+		return nil, err
+	}
+
+	output, err := cmdutil.RunAndWaitContext(ctx, "git", "-C", repoPath, "ls-files", "-z", "--", pattern)
+	if err != nil {
+		return nil, fmt.Errorf("Could not run git ls-files: %w", err)
+	}
+
+	var files []string
+	for _, file := range strings.Split(output, "\x00") {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+
+	return files, nil
+}
+
+// checkAttrBatch is the number of paths given to each git check-attr, keeping the command line
+// within the limits of the system.
+const checkAttrBatch = 500
+
+// CheckAttr returns the values of the attributes set by the .gitattributes of the repository
+// for each of the paths, as reported by git check-attr. Attributes left unspecified are not
+// returned.
+func CheckAttr(
+	ctx context.Context,
+	repoPath string,
+	attrs []string,
+	paths []string,
+) (map[string]map[string]string, error) {
+	if err := checkGitExists(); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]map[string]string)
+	for start := 0; start < len(paths); start += checkAttrBatch {
+		end := start + checkAttrBatch
+		if end > len(paths) {
+			end = len(paths)
+		}
+
+		args := append([]string{"-C", repoPath, "check-attr", "-z"}, attrs...)
+		args = append(args, "--")
+		args = append(args, paths[start:end]...)
+		output, err := cmdutil.RunAndWaitContext(ctx, "git", args...)
+		if err != nil {
+			return nil, fmt.Errorf("Could not run git check-attr: %w", err)
+		}
+
+		fields := strings.Split(output, "\x00")
+		for i := 0; i+2 < len(fields); i += 3 {
+			path, attr, value := fields[i], fields[i+1], fields[i+2]
+			if value == "unspecified" {
+				continue
+			}
+
+			if values[path] == nil {
+				values[path] = make(map[string]string)
+			}
+			values[path][attr] = value
+		}
+	}
+
+	return values, nil
+}
+
+// GeneratedFiles returns the files at HEAD holding the marker of generated code, either the
+// "Code generated ... DO NOT EDIT." header or the "@generated" tag.
+func GeneratedFiles(ctx context.Context, repoPath string) ([]string, error) {
+	if err := checkGitExists(); err != nil {
+		return nil, err
+	}
+
+	output, err := cmdutil.RunAndWaitContext(
+		ctx,
+		"git", "-C", repoPath, "grep", "-l", "-I", "-z", "-E",
+		"-e", "Code generated .* DO NOT EDIT",
+		"-e", "@generated",
+		"HEAD", "--", ".",
+	)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Could not run git grep: %w", err)
+	}
+
+	var files []string
+	for _, file := range strings.Split(output, "\x00") {
+		if file != "" {
+			files = append(files, strings.TrimPrefix(file, "HEAD:"))
+		}
+	}
+
+	return files, nil
 }
 
 // ListAllAuthors returns a list of all authors in the given repoPath.
@@ -47,7 +641,7 @@ func ListAllAuthors(repoPath string) ([]string, error) {
 
 	args := []string{
 		"-C", repoPath, "log",
-		"--format=%an (%ae)",
+		"--format=%aN (%aE)",
 	}
 
 	// Running git command
@@ -74,6 +668,46 @@ func ListAllAuthors(repoPath string) ([]string, error) {
 	return authors, nil
 }
 
+// ListAuthorPaths returns the paths changed by each author in the given repoPath.
+func ListAuthorPaths(repoPath string) (map[string][]string, error) {
+	if err := checkGitExists(); err != nil {
+		return nil, err
+	}
+
+	args := []string{
+		"-C", repoPath, "log",
+		"--format=%x1e%aN (%aE)",
+		"--name-only",
+		"-z",
+	}
+
+	output, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("could not run git log: %s", err)
+	}
+
+	paths := make(map[string][]string)
+	seen := make(map[string]bool)
+	for _, commit := range strings.Split(string(output), "\x1e") {
+		fields := strings.Split(commit, "\x00")
+		if len(fields) == 0 || !strings.Contains(fields[0], " (") {
+			continue
+		}
+
+		author := formatAuthor(strings.TrimSpace(fields[0]))
+		for _, path := range fields[1:] {
+			path = strings.TrimPrefix(path, "\n")
+			if path == "" || seen[author+"\x00"+path] {
+				continue
+			}
+			seen[author+"\x00"+path] = true
+			paths[author] = append(paths[author], path)
+		}
+	}
+
+	return paths, nil
+}
+
 func formatAuthor(input string) string {
 	name := strings.Split(input, " (")[0]
 	email := strings.TrimSuffix(strings.Split(input, " (")[1], ")")
@@ -103,7 +737,7 @@ func appendExcludeArgs(args []string, exclude []string) []string {
 // checkGitExists checks if git is installed.
 func checkGitExists() error {
 	if _, err := exec.LookPath("git"); err != nil {
-		return fmt.Errorf("Could not find git in your PATH: %s", err)
+		return fmt.Errorf("Could not find git in your PATH: %w", err)
 	}
 	return nil
 }
@@ -1,39 +1,243 @@
 package git
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
 	"io/fs"
+	"os"
+	"path"
 	"path/filepath"
 	"strings"
 )
 
-// WalkDirs walks through directories to find .git folders and runs the provided callback on
-// each.
-func WalkDirs(dirs []string, callback func(path string) error) error {
+// IgnoreFile is the name of the files listing the directories not to be searched for
+// repositories, which are honoured in any directory.
+const IgnoreFile = ".produgitignore"
+
+// WalkOptions holds the options used by WalkDirs.
+type WalkOptions struct {
+	// Submodules defines whether submodules are reported as repositories.
+	Submodules bool
+
+	// MaxDepth limits how many levels below each directory are searched, without a limit when
+	// zero.
+	MaxDepth int
+
+	// Skip holds the names or globs of the directories never descended into, e.g.
+	// "node_modules" or "build-*".
+	Skip []string
+
+	// FollowSymlinks defines whether symlinked directories are descended into. Each directory
+	// is visited once, so symlink cycles are not followed.
+	FollowSymlinks bool
+}
+
+// ignoreRule is a pattern of an ignore file. Patterns with a slash match the path relative to
+// the directory of the ignore file, and the others match the name of directories at any level
+// below it.
+type ignoreRule struct {
+	dir     string
+	pattern string
+}
+
+// walker walks a directory looking for repositories.
+type walker struct {
+	opts     *WalkOptions
+	callback func(path string) error
+	visited  map[string]bool
+}
+
+// WalkDirs walks through directories to find git repositories and runs the provided callback on
+// each, passing the root of the repository. Repositories with a .git directory, linked worktrees
+// and submodules with a .git file and bare repositories are recognised.
+func WalkDirs(dirs []string, opts *WalkOptions, callback func(path string) error) error {
+	if opts == nil {
+		opts = &WalkOptions{}
+	}
+
+	for _, pattern := range opts.Skip {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("Skip expected to be a valid glob: %s.", pattern)
+		}
+	}
+
 	for _, dir := range dirs {
-		err := filepath.WalkDir(
-			dir,
-			func(path string, d fs.DirEntry, err error) error {
-				if err != nil {
-					if strings.Contains(err.Error(), "permission denied") {
-						return nil
-					}
-					return err
-				}
-
-				if d.IsDir() && d.Name() == ".git" {
-					if err := callback(path); err != nil {
-						return err
-					}
-					return filepath.SkipDir
-				}
-
-				return nil
-			},
-		)
-		if err != nil {
+		w := &walker{opts: opts, callback: callback, visited: make(map[string]bool)}
+		if err := w.walk(dir, 0, nil); err != nil {
 			return fmt.Errorf("Walking directory failed: %w", err)
 		}
 	}
 	return nil
 }
+
+// walk searches the directory at dir, which is depth levels below the starting directory, with
+// the ignore rules of its parents.
+func (w *walker) walk(dir string, depth int, ignores []ignoreRule) error {
+	realDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrPermission) {
+			return nil
+		}
+		return err
+	}
+
+	if w.visited[realDir] {
+		return nil
+	}
+	w.visited[realDir] = true
+
+	if isBare(dir) {
+		return w.callback(dir)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrPermission) {
+			return nil
+		}
+		return err
+	}
+
+	ignores, err = readIgnoreFile(dir, ignores)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.Name() != ".git" {
+			continue
+		}
+
+		if !entry.IsDir() && isSubmodule(filepath.Join(dir, entry.Name())) && !w.opts.Submodules {
+			break
+		}
+
+		if err := w.callback(dir); err != nil {
+			return err
+		}
+		break
+	}
+
+	if w.opts.MaxDepth > 0 && depth >= w.opts.MaxDepth {
+		return nil
+	}
+
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if entry.Name() == ".git" || !w.isDir(path, entry) || w.skip(path, ignores) {
+			continue
+		}
+
+		if err := w.walk(path, depth+1, ignores); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isDir checks if the entry is a directory, or a symlink to a directory when symlinks are
+// followed.
+func (w *walker) isDir(path string, entry fs.DirEntry) bool {
+	if entry.IsDir() {
+		return true
+	}
+
+	if entry.Type()&fs.ModeSymlink == 0 || !w.opts.FollowSymlinks {
+		return false
+	}
+
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// skip checks if the directory at path must not be descended into.
+func (w *walker) skip(dir string, ignores []ignoreRule) bool {
+	name := filepath.Base(dir)
+	for _, pattern := range w.opts.Skip {
+		if matched, _ := filepath.Match(pattern, name); matched {
+			return true
+		}
+	}
+
+	for _, rule := range ignores {
+		if rule.match(dir) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// match checks if the rule matches the directory at dir.
+func (r ignoreRule) match(dir string) bool {
+	if !strings.Contains(r.pattern, "/") {
+		matched, _ := path.Match(r.pattern, filepath.Base(dir))
+		return matched
+	}
+
+	rel, err := filepath.Rel(r.dir, dir)
+	if err != nil {
+		return false
+	}
+
+	matched, _ := path.Match(strings.TrimPrefix(r.pattern, "/"), filepath.ToSlash(rel))
+	return matched
+}
+
+// readIgnoreFile appends the rules of the ignore file in dir, if any, to the rules of its
+// parents. Blank lines and lines starting with # are ignored.
+func readIgnoreFile(dir string, ignores []ignoreRule) ([]ignoreRule, error) {
+	file, err := os.Open(filepath.Join(dir, IgnoreFile))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrPermission) {
+			return ignores, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	// The rules of the parents are copied, so the rules of sibling directories do not share
+	// the same backing array.
+	rules := append([]ignoreRule(nil), ignores...)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		pattern := strings.TrimSuffix(strings.TrimSpace(scanner.Text()), "/")
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("Invalid pattern in %s: %s.", filepath.Join(dir, IgnoreFile), pattern)
+		}
+
+		rules = append(rules, ignoreRule{dir: dir, pattern: pattern})
+	}
+
+	return rules, scanner.Err()
+}
+
+// isSubmodule checks if the .git file in path points to the modules directory of a parent
+// repository. Linked worktrees point to the worktrees directory instead.
+func isSubmodule(path string) bool {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	gitDir := strings.TrimSpace(strings.TrimPrefix(string(content), "gitdir:"))
+	return strings.Contains(filepath.ToSlash(gitDir), "/modules/")
+}
+
+// isBare checks if path is a bare repository, which holds the git directory layout without a
+// working tree.
+func isBare(path string) bool {
+	for _, name := range []string{"HEAD", "objects", "refs"} {
+		if _, err := os.Stat(filepath.Join(path, name)); err != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -30,15 +30,15 @@ func newBar(
 // generateData generates the data for the bar chart.
 func (b *bar) generateData(
 	labels []string,
-	author string,
+	name string,
 	data dataMap,
 ) []opts.BarData {
 	var result []opts.BarData
 
 	for _, label := range labels {
-		authorData, ok := data[label][author]
+		value, ok := data[label][name]
 		if ok {
-			result = append(result, opts.BarData{Value: authorData})
+			result = append(result, opts.BarData{Value: value})
 		} else {
 			result = append(result, opts.BarData{Value: int32(0)})
 		}
@@ -52,20 +52,20 @@ func (b *bar) generateSeries(
 	labels []string,
 	data dataMap,
 ) {
-	for _, author := range b.authors {
+	for _, name := range b.seriesNames(data) {
 		found := false
 
 		for _, label := range labels {
-			if _, ok := data[label][author]; ok {
+			if _, ok := data[label][name]; ok {
 				found = true
 				break
 			}
 		}
 
 		if found {
-			b.renderer.AddSeries(author, b.generateData(labels, author, data))
+			b.renderer.AddSeries(name, b.generateData(labels, name, data))
 		} else {
-			b.renderer.AddSeries(author, []opts.BarData{})
+			b.renderer.AddSeries(name, []opts.BarData{})
 		}
 	}
 }
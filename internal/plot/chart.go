@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -133,12 +134,13 @@ func (p *chart[T]) defaultGlobalOpts(
 
 // generateDataMap generates a map of data from a list of logs.
 func (p *chart[T]) generateDataMap(
+	logs *data.Logs,
 	createKey func(c *chart[T], l *data.Log) string,
 	createData func(c *chart[T], l *data.Log) dataValueMap,
 ) dataMap {
 	data := make(dataMap)
 
-	for _, log := range p.logs.Logs {
+	for _, log := range logs.Logs {
 		rootKey := createKey(p, log)
 
 		if _, ok := data[rootKey]; !ok {
@@ -152,3 +154,23 @@ func (p *chart[T]) generateDataMap(
 
 	return data
 }
+
+// seriesNames returns the names of the series, which are the authors when grouping by author
+// or the sorted names found in the data otherwise.
+func (p *chart[T]) seriesNames(data dataMap) []string {
+	if p.groupBy == GroupByAuthor {
+		return p.authors
+	}
+
+	var names []string
+	for _, values := range data {
+		for name := range values {
+			if !contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
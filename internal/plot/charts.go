@@ -31,23 +31,19 @@ func NewMonthly(
 
 // Plot generates the monthly chart.
 func (m *monthly) Plot() error {
-	logs, err := data.Filter(
-		m.bar.logs,
-		data.WithDate(m.bar.startDate, m.bar.endDate),
-		data.WithAuthors(m.bar.authors),
-		data.WithMergeAuthors(m.bar.authors),
-	)
+	logs, err := data.Filter(m.bar.logs, m.bar.filterOptions()...)
 	if err != nil {
 		return err
 	}
 
 	monthLabel := m.createLabels(logs)
 	formattedData := m.bar.generateDataMap(
+		logs,
 		func(c *chart[*charts.Bar], l *data.Log) string {
-			return l.GetDate().AsTime().Format(c.dateFmt)
+			return c.logTime(l).Format(c.dateFmt)
 		},
-		func(_ *chart[*charts.Bar], l *data.Log) dataValueMap {
-			return dataValueMap{l.GetAuthor(): l.GetPlus()}
+		func(c *chart[*charts.Bar], l *data.Log) dataValueMap {
+			return dataValueMap{c.seriesKey(l): l.GetPlus()}
 		},
 	)
 
@@ -69,7 +65,7 @@ func (m *monthly) createLabels(logs *data.Logs) []string {
 			continue
 		}
 
-		monthYear := log.GetDate().AsTime().Format(m.bar.dateFmt)
+		monthYear := m.bar.logTime(log).Format(m.bar.dateFmt)
 		if _, exists := seen[monthYear]; !exists {
 			result = append(result, monthYear)
 			seen[monthYear] = struct{}{}
@@ -106,23 +102,19 @@ func NewTimeOfDay(
 
 // Plot generates the time of day chart.
 func (t *timeOfDay) Plot() error {
-	logs, err := data.Filter(
-		t.bar.logs,
-		data.WithDate(t.bar.startDate, t.bar.endDate),
-		data.WithAuthors(t.bar.authors),
-		data.WithMergeAuthors(t.bar.authors),
-	)
+	logs, err := data.Filter(t.bar.logs, t.bar.filterOptions()...)
 	if err != nil {
 		return err
 	}
 
 	timeLabel := t.createLabels(logs)
 	formattedData := t.bar.generateDataMap(
+		logs,
 		func(c *chart[*charts.Bar], l *data.Log) string {
-			return t.getTimeOfDay(l.GetDate().AsTime().Hour())
+			return t.getTimeOfDay(c.logTime(l).Hour())
 		},
-		func(_ *chart[*charts.Bar], l *data.Log) dataValueMap {
-			return dataValueMap{l.GetAuthor(): l.GetPlus()}
+		func(c *chart[*charts.Bar], l *data.Log) dataValueMap {
+			return dataValueMap{c.seriesKey(l): l.GetPlus()}
 		},
 	)
 
@@ -181,24 +173,20 @@ func NewTopLanguages(
 
 // Plot generates the top languages chart.
 func (t *topLanguages) Plot() error {
-	logs, err := data.Filter(
-		t.bar.logs,
-		data.WithDate(t.bar.startDate, t.bar.endDate),
-		data.WithAuthors(t.bar.authors),
-		data.WithMergeAuthors(t.bar.authors),
-	)
+	logs, err := data.Filter(t.bar.logs, t.bar.filterOptions()...)
 	if err != nil {
 		return err
 	}
 
 	languageLabel := t.createLabels(logs)
 	formattedData := t.bar.generateDataMap(
+		logs,
 		func(c *chart[*charts.Bar], l *data.Log) string {
 			return t.identifyLanguage(l.GetPath())
 		},
-		func(_ *chart[*charts.Bar], l *data.Log) dataValueMap {
+		func(c *chart[*charts.Bar], l *data.Log) dataValueMap {
 			return dataValueMap{
-				l.GetAuthor(): l.GetPlus(),
+				c.seriesKey(l): l.GetPlus(),
 			}
 		},
 	)
@@ -308,24 +296,20 @@ func NewWeekday(
 
 // Plot generates the weekday chart.
 func (w *weekday) Plot() error {
-	logs, err := data.Filter(
-		w.bar.logs,
-		data.WithDate(w.bar.startDate, w.bar.endDate),
-		data.WithAuthors(w.bar.authors),
-		data.WithMergeAuthors(w.bar.authors),
-	)
+	logs, err := data.Filter(w.bar.logs, w.bar.filterOptions()...)
 	if err != nil {
 		return err
 	}
 
 	weekdayLabel := w.createLabels(logs)
 	formattedData := w.bar.generateDataMap(
+		logs,
 		func(c *chart[*charts.Bar], l *data.Log) string {
-			return w.identifyWeekday(l.GetDate().AsTime())
+			return w.identifyWeekday(c.logTime(l))
 		},
-		func(_ *chart[*charts.Bar], l *data.Log) dataValueMap {
+		func(c *chart[*charts.Bar], l *data.Log) dataValueMap {
 			return dataValueMap{
-				l.GetAuthor(): l.GetPlus(),
+				c.seriesKey(l): l.GetPlus(),
 			}
 		},
 	)
@@ -378,37 +362,118 @@ func NewTopAuthors(
 
 // Plot generates the weekday chart.
 func (t *topAuthors) Plot() error {
-	logs, err := data.Filter(
-		t.pie.logs,
-		data.WithDate(t.pie.startDate, t.pie.endDate),
-		data.WithAuthors(t.pie.authors),
-		data.WithMergeAuthors(t.pie.authors),
-	)
+	logs, err := data.Filter(t.pie.logs, t.pie.filterOptions()...)
 	if err != nil {
 		return err
 	}
 
-	authorsLabel := t.createLabels(logs)
 	formattedData := t.pie.generateDataMap(
+		logs,
 		func(c *chart[*charts.Pie], l *data.Log) string {
-			return l.GetAuthor()
+			return c.seriesKey(l)
 		},
 		func(c *chart[*charts.Pie], l *data.Log) dataValueMap {
 			return dataValueMap{
-				l.GetAuthor(): l.GetPlus(),
+				c.seriesKey(l): l.GetPlus(),
 			}
 		},
 	)
 
 	t.pie.setGlobalOptions("Top Authors Report")
-	t.pie.generateSeries(authorsLabel, formattedData)
+	t.pie.generateSeries(t.pie.seriesNames(formattedData), formattedData)
 
 	return t.pie.save()
 }
 
-// createLabels creates the labels for the weekday plot.
-func (t *topAuthors) createLabels(logs *data.Logs) []string {
-	return t.pie.authors
+// pairing is a struct that represents the pairing matrix plot.
+type pairing struct {
+	heatMap *heatMap
+}
+
+// NewPairing creates a new pairing matrix plot.
+func NewPairing(
+	logs *data.Logs,
+	config *Config,
+) *pairing {
+	return &pairing{
+		heatMap: newHeatMap(
+			logs,
+			"pairing",
+			config,
+		),
+	}
+}
+
+// Plot generates the pairing chart, holding how many commits each pair of authors made
+// together, either as the author or as a co-author.
+func (p *pairing) Plot() error {
+	logs, err := data.Filter(p.heatMap.logs, p.heatMap.commitFilterOptions()...)
+	if err != nil {
+		return err
+	}
+
+	participants, err := p.participants(logs)
+	if err != nil {
+		return err
+	}
+
+	commits := make(map[string]map[string]bool)
+	for _, log := range participants.Logs {
+		if _, ok := commits[log.GetHash()]; !ok {
+			commits[log.GetHash()] = make(map[string]bool)
+		}
+		commits[log.GetHash()][log.GetAuthor()] = true
+	}
+
+	formattedData := make(dataMap)
+	var maxValue int32
+	for _, authors := range commits {
+		for author := range authors {
+			for pair := range authors {
+				if author == pair {
+					continue
+				}
+
+				if _, ok := formattedData[author]; !ok {
+					formattedData[author] = make(dataValueMap)
+				}
+
+				formattedData[author][pair]++
+				if formattedData[author][pair] > maxValue {
+					maxValue = formattedData[author][pair]
+				}
+			}
+		}
+	}
+
+	p.heatMap.setGlobalOptions("Pairing Report", p.heatMap.authors, maxValue)
+	p.heatMap.generateSeries("Commits together", p.heatMap.authors, formattedData)
+
+	return p.heatMap.save()
+}
+
+// participants returns a log for the author and for each co-author of every commit, keeping
+// only the configured authors.
+func (p *pairing) participants(logs *data.Logs) (*data.Logs, error) {
+	participants := &data.Logs{}
+	for _, log := range logs.Logs {
+		if log.GetHash() == "" {
+			continue
+		}
+
+		for _, author := range append([]string{log.GetAuthor()}, log.GetCoAuthors()...) {
+			participants.Logs = append(
+				participants.Logs,
+				&data.Log{Hash: log.GetHash(), Author: author},
+			)
+		}
+	}
+
+	return data.Filter(
+		participants,
+		data.WithAuthors(p.heatMap.authors),
+		data.WithMergeAuthors(p.heatMap.authors),
+	)
 }
 
 // contains is a helper function to check if a slice contains a string.
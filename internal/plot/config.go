@@ -8,20 +8,51 @@ import (
 	"github.com/christian-gama/produgit/internal/data"
 )
 
+const (
+	// GroupByAuthor groups the series of the charts by author.
+	GroupByAuthor = "author"
+
+	// GroupByRepo groups the series of the charts by repository.
+	GroupByRepo = "repo"
+
+	// GroupByCategory groups the series of the charts by the category of the files.
+	GroupByCategory = "category"
+
+	// GroupByProject groups the series of the charts by project.
+	GroupByProject = "project"
+)
+
+// GroupByOptions returns the accepted values for the group by option.
+func GroupByOptions() []string {
+	return []string{GroupByAuthor, GroupByRepo, GroupByCategory, GroupByProject}
+}
+
 type Config struct {
-	startDate time.Time
-	endDate   time.Time
-	authors   []string
-	period    string
-	output    string
+	startDate  time.Time
+	endDate    time.Time
+	authors    []string
+	period     string
+	output     string
+	groupBy    string
+	location   *time.Location
+	selections []data.FilterOption
+	filters    []data.FilterOption
 }
 
+// NewConfig creates a new Config. The selections are the filter options deciding on each log
+// alone, like the repositories or categories to include, which are applied while loading the
+// report as well, and the filters the other options, like the identities and the credit of
+// co-authors, which are applied to the logs of every chart.
 func NewConfig(
 	startDate time.Time,
 	endDate time.Time,
 	authors []string,
 	period string,
 	output string,
+	groupBy string,
+	location *time.Location,
+	selections []data.FilterOption,
+	filters ...data.FilterOption,
 ) (*Config, error) {
 	if len(authors) == 0 {
 		return nil, fmt.Errorf("At least one author must be provided")
@@ -71,13 +102,69 @@ func NewConfig(
 		)
 	}
 
+	if groupBy == "" {
+		groupBy = GroupByAuthor
+	}
+
+	if !contains(GroupByOptions(), groupBy) {
+		return nil, fmt.Errorf("Group by must be one of %v", GroupByOptions())
+	}
+
 	cfg := &Config{
-		startDate: startDate,
-		endDate:   endDate,
-		authors:   authors,
-		period:    period,
-		output:    output,
+		startDate:  startDate,
+		endDate:    endDate,
+		authors:    authors,
+		period:     period,
+		output:     output,
+		groupBy:    groupBy,
+		location:   location,
+		selections: selections,
+		filters:    filters,
 	}
 
 	return cfg, nil
 }
+
+// filterOptions returns the options used to filter the logs of every chart.
+func (c *Config) filterOptions() []data.FilterOption {
+	return append(
+		c.commitFilterOptions(),
+		data.WithAuthors(c.authors),
+		data.WithMergeAuthors(c.authors),
+	)
+}
+
+// commitFilterOptions returns the options used to filter the logs regardless of their authors.
+func (c *Config) commitFilterOptions() []data.FilterOption {
+	return append(c.LoadFilterOptions(), c.filters...)
+}
+
+// LoadFilterOptions returns the options that can be applied while loading the report, as every
+// chart applies them as well. They keep only the logs within the dates of the plot matching the
+// selections, so the others are never held in memory.
+func (c *Config) LoadFilterOptions() []data.FilterOption {
+	return append(
+		[]data.FilterOption{data.WithDate(c.startDate, c.endDate)},
+		c.selections...,
+	)
+}
+
+// logTime returns the date of the log in the configured location, which defaults to the
+// timezone of the author.
+func (c *Config) logTime(l *data.Log) time.Time {
+	return l.Time(c.location)
+}
+
+// seriesKey returns the name of the series the log belongs to.
+func (c *Config) seriesKey(l *data.Log) string {
+	switch c.groupBy {
+	case GroupByRepo:
+		return l.RepositoryName()
+	case GroupByCategory:
+		return l.CategoryName()
+	case GroupByProject:
+		return l.ProjectName()
+	default:
+		return l.GetAuthor()
+	}
+}
@@ -0,0 +1,83 @@
+package plot
+
+import (
+	"github.com/christian-gama/produgit/internal/data"
+	"github.com/go-echarts/go-echarts/v2/charts"
+	"github.com/go-echarts/go-echarts/v2/opts"
+)
+
+// heatMap is a struct that contains the heat map chart.
+type heatMap struct {
+	*chart[*charts.HeatMap]
+}
+
+// newHeatMap returns a new heat map chart.
+func newHeatMap(
+	logs *data.Logs,
+	chartName string,
+	config *Config,
+) *heatMap {
+	return &heatMap{
+		NewPlot[*charts.HeatMap](
+			charts.NewHeatMap(),
+			chartName,
+			config,
+			logs,
+		),
+	}
+}
+
+// generateData generates the data for the heat map chart, where each cell holds the value of
+// the x label for the y label.
+func (h *heatMap) generateData(
+	labels []string,
+	data dataMap,
+) []opts.HeatMapData {
+	var result []opts.HeatMapData
+
+	for x, xLabel := range labels {
+		for y, yLabel := range labels {
+			result = append(
+				result,
+				opts.HeatMapData{Value: [3]interface{}{x, y, data[xLabel][yLabel]}},
+			)
+		}
+	}
+
+	return result
+}
+
+// generateSeries generates the series for the heat map chart.
+func (h *heatMap) generateSeries(
+	name string,
+	labels []string,
+	data dataMap,
+) {
+	h.renderer.AddSeries(
+		name,
+		h.generateData(labels, data),
+		charts.WithLabelOpts(opts.Label{Show: true}),
+	)
+}
+
+// setGlobalOptions sets the global options for the heat map chart. The labels of the x axis are
+// set as an option, as the heat map chart does not render the ones given to SetXAxis.
+func (h *heatMap) setGlobalOptions(title string, labels []string, maxValue int32) {
+	h.renderer.SetGlobalOptions(
+		append(
+			h.defaultGlobalOpts(title),
+			charts.WithXAxisOpts(opts.XAxis{Type: "category", Data: labels}),
+			charts.WithYAxisOpts(opts.YAxis{Type: "category", Data: labels}),
+			charts.WithVisualMapOpts(opts.VisualMap{
+				Calculable: true,
+				Min:        0,
+				Max:        float32(maxValue),
+				Show:       true,
+			}),
+			charts.WithTooltipOpts(opts.Tooltip{
+				Show:    true,
+				Trigger: "item",
+			}),
+		)...,
+	)
+}
@@ -0,0 +1,76 @@
+package report
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/christian-gama/produgit/internal/data"
+	"github.com/christian-gama/produgit/internal/git"
+)
+
+const (
+	attrGenerated     = "linguist-generated"
+	attrVendored      = "linguist-vendored"
+	attrDocumentation = "linguist-documentation"
+)
+
+// classify sets the category of the logs of the repository, honouring the linguist attributes
+// of its .gitattributes and the generated code markers of the files at HEAD.
+func classify(ctx context.Context, repository string, hasHead bool, logs []*data.Log) error {
+	var paths []string
+	seen := make(map[string]bool)
+	for _, log := range logs {
+		if !seen[log.GetPath()] {
+			seen[log.GetPath()] = true
+			paths = append(paths, log.GetPath())
+		}
+	}
+
+	attrs, err := git.CheckAttr(ctx, repository, []string{attrGenerated, attrVendored, attrDocumentation}, paths)
+	if err != nil {
+		return fmt.Errorf("Reading attributes failed: %w", err)
+	}
+
+	generated := make(map[string]bool)
+	if hasHead {
+		files, err := git.GeneratedFiles(ctx, repository)
+		if err != nil {
+			return fmt.Errorf("Finding generated files failed: %w", err)
+		}
+		for _, file := range files {
+			generated[file] = true
+		}
+	}
+
+	for _, log := range logs {
+		hints := &data.FileHints{
+			Generated:     attrHint(attrs[log.GetPath()][attrGenerated]),
+			Vendored:      attrHint(attrs[log.GetPath()][attrVendored]),
+			Documentation: attrHint(attrs[log.GetPath()][attrDocumentation]),
+		}
+
+		if hints.Generated == nil && generated[log.GetPath()] {
+			isGenerated := true
+			hints.Generated = &isGenerated
+		}
+
+		log.Category = data.Classify(log.GetPath(), hints)
+	}
+
+	return nil
+}
+
+// attrHint converts the value of an attribute to a hint, which is nil when the attribute does
+// not hold a boolean.
+func attrHint(value string) *bool {
+	var hint bool
+	switch value {
+	case "set", "true":
+		hint = true
+	case "unset", "false":
+		hint = false
+	default:
+		return nil
+	}
+	return &hint
+}
@@ -0,0 +1,140 @@
+package report
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/christian-gama/produgit/internal/data"
+	"github.com/christian-gama/produgit/internal/logger"
+)
+
+// collapse tells how the logs of a repository are collapsed into the canonical repository of
+// the group sharing its history.
+type collapse struct {
+	canonical *repoResult
+	index     int
+	owners    map[string]int
+}
+
+// keep returns the logs of the repository kept in the report. The logs of the canonical
+// repository are kept as they are, while the others skip the commits already held by a
+// repository before them in the group and are relabeled with the canonical repository.
+func (c *collapse) keep(result *repoResult, logs []*data.Log) []*data.Log {
+	if c.index == 0 {
+		return logs
+	}
+
+	kept := logs[:0]
+	for _, log := range logs {
+		if log.GetHash() != "" && c.owners[log.GetHash()] < c.index {
+			continue
+		}
+
+		if log.GetSource() == "" {
+			log.Source = result.repository
+		}
+		log.Repository = c.canonical.repository
+		log.Remote = c.canonical.remote
+		kept = append(kept, log)
+	}
+
+	return kept
+}
+
+// deduplicate plans how the repositories sharing history, like clones, forks and worktrees of
+// the same project, are collapsed into a single logical repository. The repository with the
+// smallest path is the canonical one and the commits it already has are skipped in the others.
+// The logs of the other repositories keep the repository they were found in as their source,
+// so an incremental report carries them over along with the checkpoints of that repository.
+// The plan only needs the hashes of the results, so their logs may be spooled already.
+func deduplicate(results []*repoResult) map[*repoResult]*collapse {
+	sorted := make([]*repoResult, len(results))
+	copy(sorted, results)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].repository < sorted[j].repository
+	})
+
+	plan := make(map[*repoResult]*collapse, len(results))
+	for _, group := range groupByRoots(sorted) {
+		canonical := group[0]
+		plan[canonical] = &collapse{canonical: canonical}
+		if len(group) == 1 {
+			continue
+		}
+
+		owners := make(map[string]int)
+		var collapsed []string
+		skipped := 0
+		for i, member := range group {
+			for _, hash := range member.hashes {
+				if _, ok := owners[hash]; ok {
+					skipped++
+					continue
+				}
+				owners[hash] = i
+			}
+
+			if i > 0 {
+				collapsed = append(collapsed, member.repository)
+				plan[member] = &collapse{canonical: canonical, index: i, owners: owners}
+			}
+		}
+
+		logger.Print(
+			"Collapsed %s into %s, skipping %d duplicated commits",
+			strings.Join(collapsed, ", "),
+			canonical.repository,
+			skipped,
+		)
+	}
+
+	return plan
+}
+
+// groupByRoots groups the results sharing at least one root commit, keeping the order of the
+// given results inside each group.
+func groupByRoots(results []*repoResult) [][]*repoResult {
+	parent := make([]int, len(results))
+	for i := range parent {
+		parent[i] = i
+	}
+
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	owners := make(map[string]int)
+	for i, result := range results {
+		for _, root := range result.roots {
+			owner, ok := owners[root]
+			if !ok {
+				owners[root] = i
+				continue
+			}
+
+			a, b := find(owner), find(i)
+			if a < b {
+				parent[b] = a
+			} else {
+				parent[a] = b
+			}
+		}
+	}
+
+	var groups [][]*repoResult
+	index := make(map[int]int)
+	for i, result := range results {
+		root := find(i)
+		if _, ok := index[root]; !ok {
+			index[root] = len(groups)
+			groups = append(groups, nil)
+		}
+		groups[index[root]] = append(groups[index[root]], result)
+	}
+
+	return groups
+}
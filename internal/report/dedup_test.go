@@ -0,0 +1,190 @@
+package report
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/christian-gama/produgit/internal/data"
+)
+
+func TestDeduplicate(t *testing.T) {
+	type logKey struct {
+		repository string
+		hash       string
+		path       string
+	}
+
+	tests := []struct {
+		name     string
+		results  []*repoResult
+		expected map[string][]logKey
+	}{
+		{
+			name: "unrelated repositories",
+			results: []*repoResult{
+				{repository: "/b", roots: []string{"r2"}, logs: []*data.Log{{Hash: "h2", Path: "b.go"}}},
+				{repository: "/a", roots: []string{"r1"}, logs: []*data.Log{{Hash: "h1", Path: "a.go"}}},
+			},
+			expected: map[string][]logKey{
+				"/a": {{"/a", "h1", "a.go"}},
+				"/b": {{"/b", "h2", "b.go"}},
+			},
+		},
+		{
+			name: "clone sharing the root commit",
+			results: []*repoResult{
+				{
+					repository: "/fork",
+					roots:      []string{"r1"},
+					logs: []*data.Log{
+						{Repository: "/fork", Hash: "h1", Path: "main.go"},
+						{Repository: "/fork", Hash: "h3", Path: "fork.go"},
+					},
+				},
+				{
+					repository: "/clone",
+					roots:      []string{"r1"},
+					logs: []*data.Log{
+						{Repository: "/clone", Hash: "h1", Path: "main.go"},
+						{Repository: "/clone", Hash: "h2", Path: "clone.go"},
+					},
+				},
+			},
+			expected: map[string][]logKey{
+				"/clone": {
+					{"/clone", "h1", "main.go"},
+					{"/clone", "h2", "clone.go"},
+					{"/clone", "h3", "fork.go"},
+				},
+			},
+		},
+		{
+			name: "member keeps every file of a new commit",
+			results: []*repoResult{
+				{repository: "/a", roots: []string{"r1"}, logs: []*data.Log{{Hash: "h1", Path: "a.go"}}},
+				{
+					repository: "/b",
+					roots:      []string{"r1"},
+					logs:       []*data.Log{{Hash: "h2", Path: "a.go"}, {Hash: "h2", Path: "b.go"}},
+				},
+			},
+			expected: map[string][]logKey{
+				"/a": {{"/a", "h1", "a.go"}, {"/a", "h2", "a.go"}, {"/a", "h2", "b.go"}},
+			},
+		},
+		{
+			name: "groups joined through a shared member",
+			results: []*repoResult{
+				{repository: "/a", roots: []string{"r1"}, logs: []*data.Log{{Hash: "h1", Path: "a.go"}}},
+				{repository: "/b", roots: []string{"r1", "r2"}, logs: []*data.Log{{Hash: "h1", Path: "a.go"}}},
+				{repository: "/c", roots: []string{"r2"}, logs: []*data.Log{{Hash: "h2", Path: "c.go"}}},
+			},
+			expected: map[string][]logKey{
+				"/a": {{"/a", "h1", "a.go"}, {"/a", "h2", "c.go"}},
+			},
+		},
+		{
+			name: "hashless logs are kept",
+			results: []*repoResult{
+				{repository: "/a", roots: []string{"r1"}, logs: []*data.Log{{Path: "main.go"}}},
+				{repository: "/b", roots: []string{"r1"}, logs: []*data.Log{{Path: "main.go"}, {Path: "main.go"}}},
+			},
+			expected: map[string][]logKey{
+				"/a": {{"/a", "", "main.go"}, {"/a", "", "main.go"}, {"/a", "", "main.go"}},
+			},
+		},
+		{
+			name: "repositories without history",
+			results: []*repoResult{
+				{repository: "/a"},
+				{repository: "/b"},
+			},
+			expected: map[string][]logKey{"/a": nil, "/b": nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := collapseResults(tt.results)
+
+			if len(got) != len(tt.expected) {
+				t.Fatalf("deduplicate() returned %d repositories, expected %d", len(got), len(tt.expected))
+			}
+
+			for repository, logs := range got {
+				expected, ok := tt.expected[repository]
+				if !ok {
+					t.Fatalf("deduplicate() returned unexpected repository %s", repository)
+				}
+
+				if len(logs) != len(expected) {
+					t.Fatalf("deduplicate() %s has %d logs, expected %d", repository, len(logs), len(expected))
+				}
+
+				for i, log := range logs {
+					key := logKey{log.GetRepository(), log.GetHash(), log.GetPath()}
+					if key.repository == "" {
+						key.repository = repository
+					}
+					if key != expected[i] {
+						t.Errorf("deduplicate() %s log %d = %v, expected %v", repository, i, key, expected[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDeduplicate_Source(t *testing.T) {
+	results := []*repoResult{
+		{
+			repository: "/b",
+			roots:      []string{"r1"},
+			logs: []*data.Log{
+				{Repository: "/b", Hash: "h1", Path: "main.go"},
+				{Repository: "/b", Hash: "h2", Path: "b.go"},
+				{Repository: "/a", Source: "/c", Hash: "h3", Path: "c.go"},
+			},
+		},
+		{repository: "/a", roots: []string{"r1"}, logs: []*data.Log{{Repository: "/a", Hash: "h1", Path: "main.go"}}},
+	}
+
+	got := collapseResults(results)
+	if len(got) != 1 {
+		t.Fatalf("deduplicate() returned %d repositories, expected 1", len(got))
+	}
+
+	expected := []string{"/a", "/b", "/c"}
+	if len(got["/a"]) != len(expected) {
+		t.Fatalf("deduplicate() returned %d logs, expected %d", len(got["/a"]), len(expected))
+	}
+	for i, log := range got["/a"] {
+		if log.SourceName() != expected[i] {
+			t.Errorf("deduplicate() log %d source = %q, expected %q", i, log.SourceName(), expected[i])
+		}
+	}
+}
+
+// collapseResults applies the plan of deduplicate to the results in the order of their paths,
+// returning the logs kept by each canonical repository.
+func collapseResults(results []*repoResult) map[string][]*data.Log {
+	for _, result := range results {
+		result.hashes = distinctHashes(result.logs)
+	}
+	plan := deduplicate(results)
+
+	sorted := make([]*repoResult, len(results))
+	copy(sorted, results)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].repository < sorted[j].repository
+	})
+
+	collapsed := make(map[string][]*data.Log)
+	for _, result := range sorted {
+		collapse := plan[result]
+		repository := collapse.canonical.repository
+		collapsed[repository] = append(collapsed[repository], collapse.keep(result, result.logs)...)
+	}
+
+	return collapsed
+}
@@ -0,0 +1,231 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/christian-gama/produgit/internal/data"
+)
+
+// Diff holds what changed between two reports.
+type Diff struct {
+	AddedRepositories   []string
+	RemovedRepositories []string
+	AddedAuthors        []string
+	RemovedAuthors      []string
+	AddedCommits        []*data.Log
+	RemovedCommits      []*data.Log
+	Authors             []*TotalChange
+	Repositories        []*TotalChange
+	Categories          []*TotalChange
+}
+
+// TotalChange holds the line totals of an author, repository or category in both reports.
+type TotalChange struct {
+	Name     string
+	OldPlus  int64
+	OldMinus int64
+	NewPlus  int64
+	NewMinus int64
+}
+
+// summary holds the repositories, authors, commits and line totals of a report.
+type summary struct {
+	repositories map[string]*TotalChange
+	authors      map[string]*TotalChange
+	commits      map[string]*data.Log
+}
+
+// DiffReports compares the report at oldPath with the report at newPath.
+func DiffReports(oldPath, newPath string) (*Diff, error) {
+	oldLogs, err := data.Load(oldPath)
+	if err != nil {
+		return nil, fmt.Errorf("Loading report %s failed: %w", oldPath, err)
+	}
+
+	newLogs, err := data.Load(newPath)
+	if err != nil {
+		return nil, fmt.Errorf("Loading report %s failed: %w", newPath, err)
+	}
+
+	repositories := make(map[string]*TotalChange)
+	authors := make(map[string]*TotalChange)
+	categories := make(map[string]*TotalChange)
+	oldSummary := summarize(oldLogs, repositories, authors, categories, false)
+	newSummary := summarize(newLogs, repositories, authors, categories, true)
+
+	diff := &Diff{
+		AddedRepositories:   missingKeys(newSummary.repositories, oldSummary.repositories),
+		RemovedRepositories: missingKeys(oldSummary.repositories, newSummary.repositories),
+		AddedAuthors:        missingKeys(newSummary.authors, oldSummary.authors),
+		RemovedAuthors:      missingKeys(oldSummary.authors, newSummary.authors),
+		AddedCommits:        missingCommits(newSummary.commits, oldSummary.commits),
+		RemovedCommits:      missingCommits(oldSummary.commits, newSummary.commits),
+		Authors:             changedTotals(authors),
+		Repositories:        changedTotals(repositories),
+		Categories:          changedTotals(categories),
+	}
+
+	return diff, nil
+}
+
+// summarize collects the repositories, authors and commits of the logs, adding their lines to
+// the old or the new totals of the shared repository, author and category totals. The logs
+// without a commit hash count towards the totals only.
+func summarize(
+	logs *data.Logs,
+	repositories map[string]*TotalChange,
+	authors map[string]*TotalChange,
+	categories map[string]*TotalChange,
+	isNew bool,
+) *summary {
+	s := &summary{
+		repositories: make(map[string]*TotalChange),
+		authors:      make(map[string]*TotalChange),
+		commits:      make(map[string]*data.Log),
+	}
+
+	for _, log := range logs.GetLogs() {
+		repository := addTotals(repositories, log.RepositoryName(), log, isNew)
+		author := addTotals(authors, log.GetAuthor(), log, isNew)
+		addTotals(categories, log.CategoryName(), log, isNew)
+
+		s.repositories[repository.Name] = repository
+		s.authors[author.Name] = author
+
+		// Logs of reports generated by older versions have no hash and are not commits to compare.
+		if log.GetHash() == "" {
+			continue
+		}
+		if _, ok := s.commits[log.GetHash()]; !ok {
+			s.commits[log.GetHash()] = log
+		}
+	}
+
+	return s
+}
+
+// addTotals adds the lines of the log to the totals of the given name.
+func addTotals(totals map[string]*TotalChange, name string, log *data.Log, isNew bool) *TotalChange {
+	total, ok := totals[name]
+	if !ok {
+		total = &TotalChange{Name: name}
+		totals[name] = total
+	}
+
+	if isNew {
+		total.NewPlus += int64(log.GetPlus())
+		total.NewMinus += int64(log.GetMinus())
+	} else {
+		total.OldPlus += int64(log.GetPlus())
+		total.OldMinus += int64(log.GetMinus())
+	}
+
+	return total
+}
+
+// missingKeys returns the sorted keys of a that are not in b.
+func missingKeys(a, b map[string]*TotalChange) []string {
+	var keys []string
+	for key := range a {
+		if _, ok := b[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+// missingCommits returns the commits of a that are not in b, sorted by date.
+func missingCommits(a, b map[string]*data.Log) []*data.Log {
+	var commits []*data.Log
+	for hash, log := range a {
+		if _, ok := b[hash]; !ok {
+			commits = append(commits, log)
+		}
+	}
+
+	sort.Slice(commits, func(i, j int) bool {
+		return commits[i].GetDate().AsTime().Before(commits[j].GetDate().AsTime())
+	})
+	return commits
+}
+
+// changedTotals returns the totals whose lines changed, sorted by name.
+func changedTotals(totals map[string]*TotalChange) []*TotalChange {
+	var changed []*TotalChange
+	for _, total := range totals {
+		if total.OldPlus != total.NewPlus || total.OldMinus != total.NewMinus {
+			changed = append(changed, total)
+		}
+	}
+
+	sort.Slice(changed, func(i, j int) bool {
+		return changed[i].Name < changed[j].Name
+	})
+	return changed
+}
+
+// Print prints the diff.
+func (d *Diff) Print(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	printNames(tw, "Repositories", d.AddedRepositories, d.RemovedRepositories)
+	printNames(tw, "Authors", d.AddedAuthors, d.RemovedAuthors)
+
+	fmt.Fprintf(tw, "Commits: %d added, %d removed\n", len(d.AddedCommits), len(d.RemovedCommits))
+	for _, commits := range []struct {
+		sign string
+		logs []*data.Log
+	}{{"+", d.AddedCommits}, {"-", d.RemovedCommits}} {
+		for _, log := range commits.logs {
+			fmt.Fprintf(
+				tw,
+				"  %s %.7s\t%s\t%s\t%s\n",
+				commits.sign,
+				log.GetHash(),
+				log.RepositoryName(),
+				log.GetAuthor(),
+				log.GetSubject(),
+			)
+		}
+	}
+
+	printTotals(tw, "Lines per author", d.Authors)
+	printTotals(tw, "Lines per repository", d.Repositories)
+	printTotals(tw, "Lines per category", d.Categories)
+
+	return tw.Flush()
+}
+
+// printNames prints the names added and removed.
+func printNames(w io.Writer, title string, added, removed []string) {
+	fmt.Fprintf(w, "%s: %d added, %d removed\n", title, len(added), len(removed))
+	for _, name := range added {
+		fmt.Fprintf(w, "  + %s\n", name)
+	}
+	for _, name := range removed {
+		fmt.Fprintf(w, "  - %s\n", name)
+	}
+}
+
+// printTotals prints the line totals that changed.
+func printTotals(w io.Writer, title string, totals []*TotalChange) {
+	fmt.Fprintf(w, "%s: %d changed\n", title, len(totals))
+	for _, total := range totals {
+		fmt.Fprintf(
+			w,
+			"  %s\tplus %d -> %d (%+d)\tminus %d -> %d (%+d)\n",
+			total.Name,
+			total.OldPlus,
+			total.NewPlus,
+			total.NewPlus-total.OldPlus,
+			total.OldMinus,
+			total.NewMinus,
+			total.NewMinus-total.OldMinus,
+		)
+	}
+}
@@ -0,0 +1,94 @@
+package report
+
+import (
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/christian-gama/produgit/internal/data"
+)
+
+func TestDiffReports(t *testing.T) {
+	tests := []struct {
+		name              string
+		oldLogs           []*data.Log
+		newLogs           []*data.Log
+		addedCommits      []string
+		removedCommits    []string
+		changedAuthors    []string
+		addedAuthors      []string
+		addedRepositories []string
+	}{
+		{
+			name:           "added and removed commits",
+			oldLogs:        []*data.Log{{Hash: "h1", Author: "a", Plus: 1}, {Hash: "h2", Author: "a", Plus: 1}},
+			newLogs:        []*data.Log{{Hash: "h1", Author: "a", Plus: 1}, {Hash: "h3", Author: "a", Plus: 2}},
+			addedCommits:   []string{"h3"},
+			removedCommits: []string{"h2"},
+			changedAuthors: []string{"a"},
+		},
+		{
+			name:         "hashless report",
+			oldLogs:      []*data.Log{{Author: "a", Plus: 1}, {Author: "a", Plus: 2}, {Author: "b", Plus: 3}},
+			newLogs:      []*data.Log{{Hash: "h1", Author: "a", Plus: 3}, {Hash: "h2", Author: "b", Plus: 3}},
+			addedCommits: []string{"h1", "h2"},
+		},
+		{
+			name:              "hashless reports",
+			oldLogs:           []*data.Log{{Author: "a", Plus: 1, Repository: "/a"}},
+			newLogs:           []*data.Log{{Author: "a", Plus: 1, Repository: "/a"}, {Author: "b", Plus: 1, Repository: "/b"}},
+			changedAuthors:    []string{"b"},
+			addedAuthors:      []string{"b"},
+			addedRepositories: []string{"/b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			oldPath := filepath.Join(dir, "old.pb")
+			newPath := filepath.Join(dir, "new.pb")
+			writeReport(t, oldPath, &data.Report{Logs: tt.oldLogs})
+			writeReport(t, newPath, &data.Report{Logs: tt.newLogs})
+
+			diff, err := DiffReports(oldPath, newPath)
+			if err != nil {
+				t.Fatalf("DiffReports() error = %v", err)
+			}
+
+			assertStrings(t, "added commits", commitHashes(diff.AddedCommits), tt.addedCommits)
+			assertStrings(t, "removed commits", commitHashes(diff.RemovedCommits), tt.removedCommits)
+			assertStrings(t, "changed authors", totalNames(diff.Authors), tt.changedAuthors)
+			assertStrings(t, "added authors", diff.AddedAuthors, tt.addedAuthors)
+			assertStrings(t, "added repositories", diff.AddedRepositories, tt.addedRepositories)
+		})
+	}
+}
+
+// commitHashes returns the sorted hashes of the logs.
+func commitHashes(logs []*data.Log) []string {
+	var hashes []string
+	for _, log := range logs {
+		hashes = append(hashes, log.GetHash())
+	}
+	sort.Strings(hashes)
+	return hashes
+}
+
+// totalNames returns the name of each of the totals.
+func totalNames(totals []*TotalChange) []string {
+	var names []string
+	for _, total := range totals {
+		names = append(names, total.Name)
+	}
+	return names
+}
+
+// assertStrings fails the test when got does not hold the expected strings in the same order.
+func assertStrings(t *testing.T, name string, got, expected []string) {
+	t.Helper()
+
+	if !sameStrings(got, expected) {
+		t.Errorf("DiffReports() %s = %v, expected %v", name, got, expected)
+	}
+}
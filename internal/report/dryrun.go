@@ -0,0 +1,203 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/christian-gama/produgit/internal/git"
+	dateutil "github.com/christian-gama/produgit/internal/util/date"
+)
+
+// previewFiles is the number of files listed for each exclude pattern in the preview.
+const previewFiles = 5
+
+// preview prints the effective configuration and the repositories the report would process,
+// with their commit counts, estimated sizes and the files matched by each exclude pattern,
+// without writing the report.
+func (r *Report) preview(ctx context.Context, w io.Writer) error {
+	metadata, err := r.metadata()
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "Configuration:")
+	fmt.Fprintf(tw, "  Directories:\t%s\n", strings.Join(metadata.GetDirs(), ", "))
+	fmt.Fprintf(tw, "  Output:\t%s\n", r.Output)
+	fmt.Fprintf(tw, "  Excludes:\t%s\n", strings.Join(r.Exclude, ", "))
+	fmt.Fprintf(tw, "  Merge policy:\t%s\n", r.Merges)
+	fmt.Fprintf(tw, "  Incremental:\t%t\n", r.Incremental)
+	fmt.Fprintf(tw, "  Submodules:\t%t\n", r.Submodules)
+	fmt.Fprintf(tw, "  Max depth:\t%d\n", r.MaxDepth)
+	fmt.Fprintf(tw, "  Skip:\t%s\n", strings.Join(r.Skip, ", "))
+	fmt.Fprintf(tw, "  Follow symlinks:\t%t\n", r.Symlinks)
+	fmt.Fprintf(tw, "  Since:\t%s\n", formatDate(r.Since))
+	fmt.Fprintf(tw, "  Until:\t%s\n", formatDate(r.Until))
+	fmt.Fprintf(tw, "  Refs:\t%s\n", strings.Join(metadata.GetRefs(), ", "))
+	fmt.Fprintf(tw, "  Effective lines:\t%s\n", r.Effective)
+	fmt.Fprintf(tw, "  Workers:\t%d\n", r.Workers)
+	fmt.Fprintf(tw, "  Timeout:\t%s\n", r.Timeout)
+	fmt.Fprintf(tw, "  Strict:\t%t\n", r.Strict)
+	fmt.Fprintf(tw, "  Compress:\t%t\n", r.Compress)
+	fmt.Fprintf(tw, "  History:\t%s\n", r.History)
+	if len(r.FromLogs) > 0 {
+		fmt.Fprintf(tw, "  Log files:\t%s\n", strings.Join(r.FromLogs, ", "))
+		fmt.Fprintf(tw, "  Repository name:\t%s\n", r.RepoName)
+		return tw.Flush()
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	var repositories []string
+	err = git.WalkDirs(r.Dir, r.walkOptions(), func(path string) error {
+		repository, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+
+		repositories = append(repositories, repository)
+		return ctx.Err()
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\nRepositories: %d\n", len(repositories))
+	for _, repository := range repositories {
+		if err := r.previewRepository(ctx, w, repository); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// previewRepository prints the number of commits the report would scan, the estimated size and
+// the excluded files of the repository. The excluded files are not listed for bare
+// repositories, which have no working tree to match them against.
+func (r *Report) previewRepository(ctx context.Context, w io.Writer, repository string) error {
+	settings, err := r.settings(repository)
+	if err != nil {
+		fmt.Fprintf(w, "\n%s\n  Error: %s\n", repository, err)
+		return ctx.Err()
+	}
+
+	opts := &git.LogOptions{Exclude: settings.exclude, Merges: r.Merges, Since: r.Since, Until: r.Until}
+	if patterns := r.Refs.Patterns(); len(patterns) > 0 {
+		head, ref, err := git.Head(ctx, repository)
+		if err != nil {
+			fmt.Fprintf(w, "\n%s\n  Error: %s\n", repository, err)
+			return ctx.Err()
+		}
+
+		checkpoints, err := r.refCheckpoints(ctx, repository, patterns, head, ref)
+		if err != nil {
+			fmt.Fprintf(w, "\n%s\n  Error: %s\n", repository, err)
+			return ctx.Err()
+		}
+		if len(checkpoints) == 0 {
+			fmt.Fprintf(w, "\n%s\n  No refs selected\n", repository)
+			return ctx.Err()
+		}
+		opts.Revisions = checkpointHashes(checkpoints)
+	}
+
+	commits, err := git.CommitCount(ctx, repository, opts)
+	if err != nil {
+		fmt.Fprintf(w, "\n%s\n  Error: %s\n", repository, err)
+		return ctx.Err()
+	}
+
+	size, err := git.ObjectsSize(ctx, repository)
+	if err != nil {
+		fmt.Fprintf(w, "\n%s\n  Error: %s\n", repository, err)
+		return ctx.Err()
+	}
+
+	bare, err := git.IsBare(ctx, repository)
+	if err != nil {
+		fmt.Fprintf(w, "\n%s\n  Error: %s\n", repository, err)
+		return ctx.Err()
+	}
+
+	fmt.Fprintf(w, "\n%s\n  %d commits, %s\n", repository, commits, formatSize(size))
+	if r.Verbose {
+		fmt.Fprintf(w, "  %s\n", strings.ReplaceAll(settings.describe(repository), "\n", "\n  "))
+	}
+
+	if bare {
+		fmt.Fprintf(w, "  Excluded files are not listed for bare repositories\n")
+		return ctx.Err()
+	}
+
+	for _, pattern := range settings.exclude {
+		if pattern == "" {
+			continue
+		}
+
+		files, err := git.MatchingFiles(ctx, repository, pattern)
+		if err != nil {
+			fmt.Fprintf(w, "  %s: %s\n", pattern, err)
+			continue
+		}
+
+		if len(files) == 0 {
+			continue
+		}
+
+		listed := files
+		if len(listed) > previewFiles {
+			listed = listed[:previewFiles]
+		}
+
+		more := ""
+		if len(files) > len(listed) {
+			more = fmt.Sprintf(" and %d more", len(files)-len(listed))
+		}
+
+		fmt.Fprintf(
+			w,
+			"  %s excludes %d files: %s%s\n",
+			pattern,
+			len(files),
+			strings.Join(listed, ", "),
+			more,
+		)
+	}
+
+	return ctx.Err()
+}
+
+// formatSize formats a size in bytes with a binary unit.
+func formatSize(size int64) string {
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB"}
+
+	value := float64(size)
+	unit := 0
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+
+	if unit == 0 {
+		return fmt.Sprintf("%d %s", size, units[unit])
+	}
+
+	return fmt.Sprintf("%.1f %s", value, units[unit])
+}
+
+// formatDate formats the date, which is empty when zero.
+func formatDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return dateutil.ToString(date)
+}
@@ -0,0 +1,110 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os/exec"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	// ErrorGitNotFound is the kind of the errors caused by git not being installed.
+	ErrorGitNotFound = "git not found"
+
+	// ErrorPermission is the kind of the errors caused by missing permissions.
+	ErrorPermission = "permission denied"
+
+	// ErrorCorrupt is the kind of the errors caused by broken repositories.
+	ErrorCorrupt = "corrupt repository"
+
+	// ErrorTimeout is the kind of the errors caused by repositories exceeding the timeout.
+	ErrorTimeout = "timeout"
+
+	// ErrorOther is the kind of the errors not matching any other kind.
+	ErrorOther = "other"
+)
+
+// corruptMessages are the messages git prints for repositories that cannot be read.
+var corruptMessages = []string{
+	"not a git repository",
+	"corrupt",
+	"bad object",
+	"bad tree",
+	"invalid object",
+	"unable to read",
+	"loose object",
+	"bad revision",
+}
+
+// RepoError is the error a repository failed with.
+type RepoError struct {
+	Repository string
+	Kind       string
+	Err        error
+}
+
+// newRepoError creates a RepoError, classifying the kind of the error.
+func newRepoError(repository string, err error) *RepoError {
+	return &RepoError{Repository: repository, Kind: errorKind(err), Err: err}
+}
+
+func (e *RepoError) Error() string {
+	return fmt.Sprintf("%s (%s): %s", e.Repository, e.Kind, e.Err)
+}
+
+func (e *RepoError) Unwrap() error {
+	return e.Err
+}
+
+// errorKind returns the kind of the error a repository failed with.
+func errorKind(err error) string {
+	message := strings.ToLower(err.Error())
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorTimeout
+	case errors.Is(err, exec.ErrNotFound):
+		return ErrorGitNotFound
+	case errors.Is(err, fs.ErrPermission),
+		strings.Contains(message, "permission denied"),
+		strings.Contains(message, "dubious ownership"):
+		return ErrorPermission
+	}
+
+	for _, corruptMessage := range corruptMessages {
+		if strings.Contains(message, corruptMessage) {
+			return ErrorCorrupt
+		}
+	}
+
+	return ErrorOther
+}
+
+// FailedError is returned when some repositories failed while the others were saved in the
+// report.
+type FailedError struct {
+	Errors       []*RepoError
+	Repositories int
+}
+
+func (e *FailedError) Error() string {
+	return fmt.Sprintf("%d of %d repositories failed.", len(e.Errors), e.Repositories)
+}
+
+// printSummary prints a table with the repositories that failed and why.
+func printSummary(w io.Writer, repoErrors []*RepoError, repositories int) error {
+	fmt.Fprintf(w, "\n%d of %d repositories failed:\n", len(repoErrors), repositories)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "REPOSITORY\tKIND\tERROR")
+	for _, repoErr := range repoErrors {
+		message := strings.ReplaceAll(repoErr.Err.Error(), "\n", " ")
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", repoErr.Repository, repoErr.Kind, message)
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,97 @@
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/christian-gama/produgit/internal/data"
+	"github.com/christian-gama/produgit/internal/logger"
+)
+
+// processLogFiles processes the files holding pre-captured git logs, adding each one to the
+// spool. The repositories processed are returned.
+func (r *Report) processLogFiles(spool *spool) (map[string]bool, error) {
+	processed := make(map[string]bool)
+
+	for _, path := range r.FromLogs {
+		result, err := r.processLogFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		if err := spool.add(result); err != nil {
+			return nil, err
+		}
+		processed[result.repository] = true
+	}
+
+	return processed, nil
+}
+
+// processLogFile parses the git log in the file at path, or in stdin for "-", labeling the logs
+// with the repository name.
+func (r *Report) processLogFile(path string) (*repoResult, error) {
+	repository := r.RepoName
+	if repository == "" {
+		if path == "-" {
+			return nil, fmt.Errorf("Repository name must be set with --repo-name when reading from stdin.")
+		}
+		repository = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	logger.Print("Processing %s as %s", path, repository)
+
+	var reader io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	rawLogs, err := readLines(reader)
+	if err != nil {
+		return nil, fmt.Errorf("Reading logs failed: %w", err)
+	}
+
+	parsedLogs, err := data.Parse(rawLogs)
+	if err != nil {
+		return nil, fmt.Errorf("Parsing logs failed: %w", err)
+	}
+
+	// Only the paths are known of the files of pre-captured logs.
+	for _, log := range parsedLogs {
+		log.Repository = repository
+		log.Category = data.Classify(log.GetPath(), nil)
+	}
+
+	identityLogs, err := data.Filter(
+		&data.Logs{Logs: parsedLogs},
+		data.WithIdentities(r.Identities),
+		data.WithProjectMapping(r.Projects),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Resolving identities and projects failed: %w", err)
+	}
+
+	return &repoResult{repository: repository, logs: identityLogs.Logs}, nil
+}
+
+// readLines reads the lines of r, without their line endings.
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimSuffix(scanner.Text(), "\r"))
+	}
+
+	return lines, scanner.Err()
+}
@@ -0,0 +1,172 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// snapshotPrefix and snapshotExt surround the timestamp in the names of the snapshots.
+	snapshotPrefix = "report-"
+	snapshotExt    = ".pb"
+
+	// snapshotTimeFormat is the format of the timestamp in the names of the snapshots, which
+	// sorts them chronologically.
+	snapshotTimeFormat = "20060102T150405.000000000Z"
+)
+
+// Snapshot is a report kept in the history directory.
+type Snapshot struct {
+	Name string
+	Path string
+	Time time.Time
+	Size int64
+}
+
+// ListSnapshots returns the snapshots of the history directory, from the oldest to the newest.
+func ListSnapshots(dir string) ([]*Snapshot, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("Reading history directory failed: %w", err)
+	}
+
+	var snapshots []*Snapshot
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() ||
+			!strings.HasPrefix(name, snapshotPrefix) ||
+			!strings.HasSuffix(name, snapshotExt) {
+			continue
+		}
+
+		at, err := time.Parse(
+			snapshotTimeFormat,
+			strings.TrimSuffix(strings.TrimPrefix(name, snapshotPrefix), snapshotExt),
+		)
+		if err != nil {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+
+		snapshots = append(snapshots, &Snapshot{
+			Name: name,
+			Path: filepath.Join(dir, name),
+			Time: at,
+			Size: info.Size(),
+		})
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Time.Before(snapshots[j].Time)
+	})
+
+	return snapshots, nil
+}
+
+// SaveSnapshot copies the report into the history directory, naming it after the given time.
+func SaveSnapshot(dir string, reportPath string, at time.Time) (*Snapshot, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("Creating history directory failed: %w", err)
+	}
+
+	name := snapshotPrefix + at.UTC().Format(snapshotTimeFormat) + snapshotExt
+	path := filepath.Join(dir, name)
+	if err := copyFileAtomic(reportPath, path); err != nil {
+		return nil, err
+	}
+
+	return &Snapshot{Name: name, Path: path, Time: at}, nil
+}
+
+// RestoreSnapshot replaces the report at output with the snapshot of the given name.
+func RestoreSnapshot(dir string, name string, output string) error {
+	if filepath.Base(name) != name {
+		return fmt.Errorf("Snapshot expected to be a file name: %s.", name)
+	}
+
+	path := filepath.Join(dir, name)
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("Snapshot not found: %s.", name)
+	}
+
+	return copyFileAtomic(path, output)
+}
+
+// PruneSnapshots removes the snapshots of the history directory but the newest keep ones,
+// returning the snapshots removed.
+func PruneSnapshots(dir string, keep int) ([]*Snapshot, error) {
+	if keep < 0 {
+		return nil, fmt.Errorf("Snapshots to keep cannot be negative.")
+	}
+
+	snapshots, err := ListSnapshots(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(snapshots) <= keep {
+		return nil, nil
+	}
+
+	pruned := snapshots[:len(snapshots)-keep]
+	for _, snapshot := range pruned {
+		if err := os.Remove(snapshot.Path); err != nil {
+			return nil, fmt.Errorf("Removing snapshot failed: %w", err)
+		}
+	}
+
+	return pruned, nil
+}
+
+// copyFileAtomic copies the file at src to dst atomically.
+func copyFileAtomic(src string, dst string) error {
+	file, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return writeFileAtomic(dst, func(w io.Writer) error {
+		_, err := io.Copy(w, file)
+		return err
+	})
+}
+
+// writeFileAtomic writes a file through a temporary file in the same directory, which is
+// renamed to path once fully written, so path holds either the previous or the new content
+// even if writing is interrupted.
+func writeFileAtomic(path string, write func(w io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("Creating temporary file failed: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if err := write(tmp); err != nil {
+		return fmt.Errorf("Writing file failed: %w", err)
+	}
+
+	if err := tmp.Sync(); err != nil {
+		return fmt.Errorf("Syncing file failed: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("Closing file failed: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("Renaming file failed: %w", err)
+	}
+
+	return nil
+}
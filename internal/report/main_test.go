@@ -0,0 +1,19 @@
+package report
+
+import (
+	"os"
+	"testing"
+
+	"github.com/christian-gama/produgit/config"
+)
+
+func TestMain(m *testing.M) {
+	cfg, err := config.New()
+	if err != nil {
+		panic(err)
+	}
+	cfg.Quiet = true
+	config.Config = cfg
+
+	os.Exit(m.Run())
+}
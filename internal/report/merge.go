@@ -0,0 +1,135 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/christian-gama/produgit/internal/data"
+	"github.com/christian-gama/produgit/internal/logger"
+	"github.com/christian-gama/produgit/internal/version"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// mixedMergePolicy is the merge policy of a report merged from reports generated with
+// different merge policies.
+const mixedMergePolicy = "mixed"
+
+// MergeOptions holds the options used by Merge.
+type MergeOptions struct {
+	// Identities maps the canonical identities to their aliases, reconciling the authors of
+	// the reports.
+	Identities map[string][]string
+
+	// Compress defines whether the merged report is compressed with gzip.
+	Compress bool
+}
+
+// Merge combines the reports at the given paths into the report at output. A log found in
+// several reports, identified by its commit and path, is kept once, while the logs without a
+// commit hash are always kept. The provenance of each report is recorded in the metadata of
+// the merged report.
+func Merge(paths []string, output string, opts *MergeOptions) error {
+	if len(paths) < 2 {
+		return fmt.Errorf("At least two reports must be provided to be merged.")
+	}
+
+	merged := &data.Report{
+		Metadata: &data.Metadata{
+			SchemaVersion:   data.SchemaVersion,
+			GeneratedAt:     timestamppb.Now(),
+			ProdugitVersion: version.Get(),
+		},
+	}
+
+	seenLogs := make(map[string]bool)
+	seenCheckpoints := make(map[string]bool)
+	duplicated := 0
+	hashless := 0
+
+	for _, path := range paths {
+		report, err := data.LoadReport(path)
+		if err != nil {
+			return fmt.Errorf("Loading report %s failed: %w", path, err)
+		}
+
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return fmt.Errorf("Could not convert to absolute path: %w", err)
+		}
+
+		metadata := report.GetMetadata()
+		merged.Metadata.Sources = append(merged.Metadata.Sources, &data.Source{
+			Path:            absPath,
+			GeneratedAt:     metadata.GetGeneratedAt(),
+			ProdugitVersion: metadata.GetProdugitVersion(),
+			Dirs:            metadata.GetDirs(),
+			Logs:            uint32(len(report.GetLogs())),
+		})
+
+		switch merged.Metadata.GetMergePolicy() {
+		case "":
+			merged.Metadata.MergePolicy = metadata.GetMergePolicy()
+		case metadata.GetMergePolicy():
+		default:
+			merged.Metadata.MergePolicy = mixedMergePolicy
+		}
+
+		for _, log := range report.GetLogs() {
+			// Logs of reports generated by older versions have no hash and cannot be told apart.
+			if log.GetHash() == "" {
+				hashless++
+			} else {
+				key := log.GetHash() + "\x00" + log.GetPath()
+				if seenLogs[key] {
+					duplicated++
+					continue
+				}
+				seenLogs[key] = true
+			}
+
+			merged.Logs = append(merged.Logs, log)
+		}
+
+		for _, checkpoint := range report.GetCheckpoints() {
+			key := checkpoint.GetRepository() + "\x00" + checkpoint.GetRef()
+			if !seenCheckpoints[key] {
+				seenCheckpoints[key] = true
+				merged.Checkpoints = append(merged.Checkpoints, checkpoint)
+			}
+		}
+	}
+
+	if hashless > 0 {
+		logger.Warn("Kept %d logs without a commit hash, which cannot be deduplicated", hashless)
+	}
+
+	if merged.Metadata.GetMergePolicy() == mixedMergePolicy {
+		logger.Warn("The reports were generated with different merge policies")
+	}
+
+	identityLogs, err := data.Filter(
+		&data.Logs{Logs: merged.Logs},
+		data.WithIdentities(opts.Identities),
+	)
+	if err != nil {
+		return fmt.Errorf("Resolving identities failed: %w", err)
+	}
+	merged.Logs = identityLogs.Logs
+
+	err = writeFileAtomic(output, func(w io.Writer) error {
+		return data.WriteStream(w, merged, opts.Compress)
+	})
+	if err != nil {
+		return fmt.Errorf("Saving report failed: %w", err)
+	}
+
+	logger.Print(
+		"Merged %d reports into %s, skipping %d duplicated logs",
+		len(paths),
+		output,
+		duplicated,
+	)
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/christian-gama/produgit/internal/data"
+)
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name     string
+		reports  [][]*data.Log
+		expected []string
+	}{
+		{
+			name: "duplicated logs",
+			reports: [][]*data.Log{
+				{{Hash: "h1", Path: "main.go"}, {Hash: "h1", Path: "go.mod"}},
+				{{Hash: "h1", Path: "main.go"}, {Hash: "h2", Path: "main.go"}},
+			},
+			expected: []string{"h1 main.go", "h1 go.mod", "h2 main.go"},
+		},
+		{
+			name: "hashless logs",
+			reports: [][]*data.Log{
+				{{Path: "main.go"}, {Path: "main.go"}, {Path: "main.go"}},
+				{{Path: "main.go"}},
+			},
+			expected: []string{" main.go", " main.go", " main.go", " main.go"},
+		},
+		{
+			name: "hashless and hashed logs",
+			reports: [][]*data.Log{
+				{{Path: "main.go"}, {Hash: "h1", Path: "main.go"}},
+				{{Hash: "h1", Path: "main.go"}, {Path: "main.go"}},
+			},
+			expected: []string{" main.go", "h1 main.go", " main.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			var paths []string
+			for i, logs := range tt.reports {
+				path := filepath.Join(dir, fmt.Sprintf("report%d.pb", i))
+				writeReport(t, path, &data.Report{Logs: logs})
+				paths = append(paths, path)
+			}
+
+			output := filepath.Join(dir, "merged.pb")
+			if err := Merge(paths, output, &MergeOptions{}); err != nil {
+				t.Fatalf("Merge() error = %v", err)
+			}
+
+			merged, err := data.LoadReport(output)
+			if err != nil {
+				t.Fatalf("LoadReport() error = %v", err)
+			}
+
+			if len(merged.GetLogs()) != len(tt.expected) {
+				t.Fatalf("Merge() returned %d logs, expected %d", len(merged.GetLogs()), len(tt.expected))
+			}
+			for i, log := range merged.GetLogs() {
+				if got := log.GetHash() + " " + log.GetPath(); got != tt.expected[i] {
+					t.Errorf("Merge() log %d = %q, expected %q", i, got, tt.expected[i])
+				}
+			}
+
+			if sources := merged.GetMetadata().GetSources(); len(sources) != len(tt.reports) {
+				t.Errorf("Merge() recorded %d sources, expected %d", len(sources), len(tt.reports))
+			}
+		})
+	}
+}
+
+// writeReport writes the report to the file at path.
+func writeReport(t *testing.T, path string, report *data.Report) {
+	t.Helper()
+
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	defer file.Close()
+
+	if err := data.WriteStream(file, report, false); err != nil {
+		t.Fatalf("WriteStream() error = %v", err)
+	}
+}
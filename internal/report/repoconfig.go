@@ -0,0 +1,149 @@
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+)
+
+// RepoConfigFile is the name of the optional file at the root of a repository overriding the
+// configuration for that repository.
+const RepoConfigFile = ".produgit.toml"
+
+// repoConfig is the configuration of a repository, merged on top of the global configuration.
+type repoConfig struct {
+	Exclude    []string            `toml:"exclude"`
+	Projects   map[string][]string `toml:"projects"`
+	Identities map[string][]string `toml:"identities"`
+}
+
+// repoSettings holds the effective configuration used for a repository.
+type repoSettings struct {
+	file       string
+	exclude    []string
+	projects   map[string][]string
+	identities map[string][]string
+}
+
+// loadRepoConfig loads the configuration file of the repository, which is nil when the
+// repository does not have one.
+func loadRepoConfig(repository string) (*repoConfig, string, error) {
+	path := filepath.Join(repository, RepoConfigFile)
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	defer file.Close()
+
+	cfg := &repoConfig{}
+	if err := toml.NewDecoder(file).Strict(true).Decode(cfg); err != nil {
+		return nil, "", fmt.Errorf("Invalid %s: %w", path, err)
+	}
+
+	return cfg, path, nil
+}
+
+// settings returns the effective configuration of the repository: the excludes of its
+// configuration file are added to the global ones, and its projects and identities are merged
+// with the global ones.
+func (r *Report) settings(repository string) (*repoSettings, error) {
+	cfg, path, err := loadRepoConfig(repository)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &repoSettings{
+		file:       path,
+		exclude:    r.Exclude,
+		projects:   r.Projects,
+		identities: r.Identities,
+	}
+	if cfg == nil {
+		return s, nil
+	}
+
+	s.exclude = append(append([]string{}, r.Exclude...), cfg.Exclude...)
+	s.projects = mergeMaps(r.Projects, cfg.Projects)
+	s.identities = mergeMaps(r.Identities, cfg.Identities)
+
+	return s, nil
+}
+
+// mergeMaps returns a copy of base with the values of each key of extra appended.
+func mergeMaps(base, extra map[string][]string) map[string][]string {
+	merged := make(map[string][]string, len(base)+len(extra))
+	for key, values := range base {
+		merged[key] = append([]string{}, values...)
+	}
+	for key, values := range extra {
+		merged[key] = append(merged[key], values...)
+	}
+	return merged
+}
+
+// describe describes the effective configuration of the repository for the verbose output.
+func (s *repoSettings) describe(repository string) string {
+	var b strings.Builder
+
+	file := "none"
+	if s.file != "" {
+		file = s.file
+	}
+
+	fmt.Fprintf(&b, "Configuration of %s:\n", repository)
+	fmt.Fprintf(&b, "  Config file: %s\n", file)
+	fmt.Fprintf(&b, "  Excludes: %s\n", strings.Join(s.exclude, ", "))
+	fmt.Fprintf(&b, "  Projects: %s\n", describeMap(s.projects))
+	fmt.Fprintf(&b, "  Identities: %s", describeMap(s.identities))
+
+	return b.String()
+}
+
+// fingerprint returns a hash of the effective configuration, stored in the checkpoints of the
+// repository so an incremental report rescans it when the configuration changes.
+func (s *repoSettings) fingerprint() string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf(
+		"exclude=%s\nprojects=%s\nidentities=%s",
+		strings.Join(s.exclude, "\x00"),
+		describeMap(s.projects),
+		describeMap(s.identities),
+	)))
+	return hex.EncodeToString(sum[:])
+}
+
+// describeMap describes the keys of the map along with their values, sorted by key.
+func describeMap(m map[string][]string) string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	entries := make([]string, 0, len(keys))
+	for _, key := range keys {
+		entries = append(entries, fmt.Sprintf("%s = [%s]", key, strings.Join(m[key], ", ")))
+	}
+
+	return strings.Join(entries, "; ")
+}
+
+// RepoProjects returns the path patterns of each project for the repository, merging the
+// projects of its configuration file with the given global ones.
+func RepoProjects(repository string, projects map[string][]string) (map[string][]string, error) {
+	r := &Report{Projects: projects}
+	settings, err := r.settings(repository)
+	if err != nil {
+		return nil, err
+	}
+	return settings.projects, nil
+}
@@ -1,133 +1,991 @@
 package report
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
-	"strings"
+	"runtime"
 	"sync"
+	"time"
 
 	"github.com/christian-gama/produgit/internal/data"
 	"github.com/christian-gama/produgit/internal/git"
 	"github.com/christian-gama/produgit/internal/logger"
-	"google.golang.org/protobuf/proto"
+	"github.com/christian-gama/produgit/internal/version"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Report is the configuration for the report command.
 type Report struct {
-	Dir     []string
-	Exclude []string
-	Output  string
+	Dir         []string
+	Exclude     []string
+	Output      string
+	Incremental bool
+	Submodules  bool
+	Identities  map[string][]string
+	Merges      string
+	Workers     int
+	Timeout     time.Duration
+	Strict      bool
+	Compress    bool
+	History     string
+	FromLogs    []string
+	RepoName    string
+	DryRun      bool
+	MaxDepth    int
+	Skip        []string
+	Symlinks    bool
+	Since       time.Time
+	Until       time.Time
+	Refs        *git.RefSelection
+	Effective   string
+	Verbose     bool
+	Projects    map[string][]string
+
+	previous *data.Report
+}
+
+// Option represents an option of the report.
+type Option func(r *Report)
+
+// WithIncremental defines whether only the commits newer than the previous report are fetched.
+func WithIncremental(incremental bool) Option {
+	return func(r *Report) {
+		r.Incremental = incremental
+	}
+}
+
+// WithSubmodules defines whether submodules are included in the report.
+func WithSubmodules(submodules bool) Option {
+	return func(r *Report) {
+		r.Submodules = submodules
+	}
+}
+
+// WithIdentities sets the aliases of each canonical identity, resolved in the report.
+func WithIdentities(identities map[string][]string) Option {
+	return func(r *Report) {
+		r.Identities = identities
+	}
+}
+
+// WithMerges sets the policy for merge commits, which is one of git.MergePolicies.
+func WithMerges(merges string) Option {
+	return func(r *Report) {
+		r.Merges = merges
+	}
+}
+
+// WithWorkers sets the number of repositories processed concurrently.
+func WithWorkers(workers int) Option {
+	return func(r *Report) {
+		r.Workers = workers
+	}
+}
+
+// WithTimeout sets the time a repository is given to be processed, without a limit when zero.
+func WithTimeout(timeout time.Duration) Option {
+	return func(r *Report) {
+		r.Timeout = timeout
+	}
+}
+
+// WithStrict stops the report at the first repository that fails, without saving it.
+func WithStrict(strict bool) Option {
+	return func(r *Report) {
+		r.Strict = strict
+	}
+}
+
+// WithCompress compresses the report with gzip.
+func WithCompress(compress bool) Option {
+	return func(r *Report) {
+		r.Compress = compress
+	}
+}
+
+// WithHistory keeps a snapshot of each report in the given directory.
+func WithHistory(dir string) Option {
+	return func(r *Report) {
+		r.History = dir
+	}
+}
+
+// WithFromLogs reads the logs from the given files, or from stdin for "-", holding the output
+// of git log in the format of git.GetLog, instead of walking the directories. The logs are
+// labeled with the given repository name, which defaults to the name of each file.
+func WithFromLogs(paths []string, repoName string) Option {
+	return func(r *Report) {
+		r.FromLogs = paths
+		r.RepoName = repoName
+	}
+}
+
+// WithDryRun previews what the report would process instead of generating it.
+func WithDryRun(dryRun bool) Option {
+	return func(r *Report) {
+		r.DryRun = dryRun
+	}
+}
+
+// WithMaxDepth limits how many levels below each directory are searched for repositories.
+func WithMaxDepth(maxDepth int) Option {
+	return func(r *Report) {
+		r.MaxDepth = maxDepth
+	}
+}
+
+// WithSkip sets the names or globs of the directories never searched for repositories.
+func WithSkip(skip []string) Option {
+	return func(r *Report) {
+		r.Skip = skip
+	}
+}
+
+// WithSymlinks follows the symlinks to directories when searching for repositories.
+func WithSymlinks(symlinks bool) Option {
+	return func(r *Report) {
+		r.Symlinks = symlinks
+	}
+}
+
+// WithDateRange limits the history to the commits committed between since and until. A zero
+// time leaves that end of the range open.
+func WithDateRange(since, until time.Time) Option {
+	return func(r *Report) {
+		r.Since = since
+		r.Until = until
+	}
+}
+
+// WithRefs sets the refs whose history is reported instead of HEAD. Commits reachable from
+// several refs are reported once.
+func WithRefs(refs *git.RefSelection) Option {
+	return func(r *Report) {
+		r.Refs = refs
+	}
+}
+
+// WithEffective sets the mode of the effective line counts stored along with the raw ones,
+// which is one of data.EffectiveModes.
+func WithEffective(mode string) Option {
+	return func(r *Report) {
+		r.Effective = mode
+	}
+}
+
+// WithProjects sets the path patterns of each project, assigning the logs to the projects.
+func WithProjects(projects map[string][]string) Option {
+	return func(r *Report) {
+		r.Projects = projects
+	}
+}
+
+// WithVerbose prints the effective configuration of each repository.
+func WithVerbose(verbose bool) Option {
+	return func(r *Report) {
+		r.Verbose = verbose
+	}
 }
 
 // NewReport creates a new Report.
-func NewReport(dir []string, exclude []string, output string) *Report {
-	return &Report{
-		Dir:     dir,
-		Exclude: exclude,
-		Output:  output,
+func NewReport(dir []string, exclude []string, output string, options ...Option) *Report {
+	r := &Report{
+		Dir:        dir,
+		Exclude:    exclude,
+		Output:     output,
+		Submodules: true,
+		Merges:     git.MergesDefault,
+		Workers:    runtime.NumCPU(),
+		Effective:  data.EffectiveOff,
+	}
+
+	for _, option := range options {
+		option(r)
 	}
+
+	return r
 }
 
-// Generate generates the report.
-func (r *Report) Generate() error {
-	logs, err := r.processDir()
+// repoResult holds the outcome of processing a single repository.
+type repoResult struct {
+	repository  string
+	remote      string
+	roots       []string
+	logs        []*data.Log
+	hashes      []string
+	checkpoints []*data.Checkpoint
+}
+
+// Generate generates the report. When the context is cancelled, the report is saved with the
+// repositories processed so far, while the others keep the data of the report being replaced.
+func (r *Report) Generate(ctx context.Context) error {
+	if !contains(git.MergePolicies(), r.Merges) {
+		return fmt.Errorf("Merge policy must be one of %v", git.MergePolicies())
+	}
+
+	if !contains(data.EffectiveModes(), r.Effective) {
+		return fmt.Errorf("Effective lines must be one of %v", data.EffectiveModes())
+	}
+
+	if _, err := data.ResolveProjects(r.Projects, nil); err != nil {
+		return err
+	}
+
+	if r.Workers < 1 {
+		return fmt.Errorf("Workers must be greater than zero.")
+	}
+
+	if !r.Since.IsZero() && !r.Until.IsZero() && r.Until.Before(r.Since) {
+		return fmt.Errorf("Until must not be before since.")
+	}
+
+	if r.DryRun {
+		return r.preview(ctx, os.Stdout)
+	}
+
+	if r.Incremental {
+		previous, err := r.loadPrevious()
+		if err != nil {
+			return fmt.Errorf("Loading previous report failed: %w", err)
+		}
+		r.previous = previous
+	}
+
+	spool, err := newSpool(r.Output)
 	if err != nil {
-		return fmt.Errorf("Processing directory failed: %w", err)
+		return err
 	}
+	defer spool.close()
 
-	err = r.save(&data.Logs{Logs: logs})
+	var processed map[string]bool
+	var failed *FailedError
+	if len(r.FromLogs) > 0 {
+		processed, err = r.processLogFiles(spool)
+		if err != nil {
+			return fmt.Errorf("Processing log files failed: %w", err)
+		}
+	} else {
+		processed, failed, err = r.processDir(ctx, spool)
+		if err != nil {
+			return fmt.Errorf("Processing directory failed: %w", err)
+		}
+	}
+
+	err = r.save(spool, processed)
 	if err != nil {
 		return fmt.Errorf("Saving report failed: %w", err)
 	}
 
+	if failed != nil {
+		if err := printSummary(os.Stderr, failed.Errors, failed.Repositories); err != nil {
+			return err
+		}
+	}
+
+	if ctx.Err() != nil {
+		return fmt.Errorf("Report generation was interrupted: %w", ctx.Err())
+	}
+
+	if failed != nil {
+		return failed
+	}
+
 	return nil
 }
 
-// processGitDir processes a .git directory to fetch and parse logs.
-func (r *Report) processGitDir(
-	path string,
-	results chan []*data.Log,
-	errs chan error,
-	wg *sync.WaitGroup,
-) {
-	defer wg.Done()
-	logger.Print("Processing %s", strings.TrimSuffix(path, "/.git"))
+// loadPrevious loads the report being updated in incremental mode. A missing report, or one
+// generated with different options, results in a full scan.
+func (r *Report) loadPrevious() (*data.Report, error) {
+	previous, err := data.LoadReport(r.Output)
+	if errors.Is(err, os.ErrNotExist) {
+		logger.Print("No previous report found at %s, running a full scan", r.Output)
+		return &data.Report{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if reason := r.incompatibility(previous); reason != "" {
+		logger.Print("The previous report %s, running a full scan", reason)
+		return &data.Report{}, nil
+	}
+
+	return previous, nil
+}
+
+// incompatibility describes why the data of the previous report cannot be reused with the
+// options of this report, which is empty when it can.
+func (r *Report) incompatibility(previous *data.Report) string {
+	metadata := previous.GetMetadata()
+
+	if len(metadata.GetLogSources()) > 0 {
+		return "was generated from pre-captured logs"
+	}
+
+	if metadata.GetMergePolicy() != r.Merges {
+		return fmt.Sprintf("used the %s merge policy instead of %s", metadata.GetMergePolicy(), r.Merges)
+	}
+
+	if !sameSet(metadata.GetExcludes(), r.Exclude) {
+		return "used different excludes"
+	}
+
+	if !sameTime(metadata.GetSince(), r.Since) ||
+		!sameTime(metadata.GetUntil(), r.Until) ||
+		!sameStrings(metadata.GetRefs(), r.Refs.Patterns()) {
+		return "covered a different history"
+	}
+
+	if previousEffective := metadata.GetEffectiveLines(); previousEffective != r.Effective &&
+		(previousEffective != "" || r.Effective != data.EffectiveOff) {
+		return "used different effective lines"
+	}
 
-	var localLogs []*data.Log
+	return ""
+}
 
-	rawLogs, err := git.GetLog(filepath.Dir(path), r.Exclude)
+// loadInterrupted loads the report being replaced when a full scan is interrupted, so the
+// repositories not processed yet keep its data instead of being dropped. A report generated
+// with different options cannot be combined with this one, and is kept as is.
+func (r *Report) loadInterrupted() error {
+	previous, err := data.LoadReport(r.Output)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
 	if err != nil {
-		errs <- fmt.Errorf("Getting logs failed: %w", err)
-		return
+		return fmt.Errorf("Loading previous report failed: %w", err)
+	}
+
+	if reason := r.incompatibility(previous); reason != "" {
+		return fmt.Errorf("Interrupted, keeping the previous report as it %s", reason)
+	}
+
+	r.previous = previous
+	return nil
+}
+
+// processGitDir processes a git repository to fetch and parse logs. The repository is given up
+// when it takes longer than the timeout.
+func (r *Report) processGitDir(ctx context.Context, path string) (*repoResult, error) {
+	logger.Print("Processing %s", path)
+
+	if r.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.Timeout)
+		defer cancel()
+	}
+
+	repository, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("Could not convert to absolute path: %w", err)
+	}
+
+	settings, err := r.settings(repository)
+	if err != nil {
+		return nil, fmt.Errorf("Loading repository configuration failed: %w", err)
+	}
+	if r.Verbose {
+		logger.Print("%s", settings.describe(repository))
+	}
+
+	fingerprint := settings.fingerprint()
+	reuse := r.reusable(repository, fingerprint)
+	if !reuse {
+		logger.Print("Configuration of %s changed, running a full scan", repository)
+	}
+
+	head, ref, err := git.Head(ctx, repository)
+	if err != nil {
+		return nil, fmt.Errorf("Getting HEAD failed: %w", err)
+	}
+
+	remote, err := git.RemoteURL(ctx, repository)
+	if err != nil {
+		return nil, fmt.Errorf("Getting remote failed: %w", err)
+	}
+
+	result := &repoResult{repository: repository, remote: remote}
+	if head != "" {
+		result.checkpoints = []*data.Checkpoint{
+			{Repository: repository, Ref: ref, Hash: head, Settings: fingerprint},
+		}
+
+		result.roots, err = git.RootCommits(ctx, repository)
+		if err != nil {
+			return nil, fmt.Errorf("Getting root commits failed: %w", err)
+		}
+	}
+
+	opts := &git.LogOptions{Exclude: settings.exclude, Merges: r.Merges, Since: r.Since, Until: r.Until}
+	if patterns := r.Refs.Patterns(); len(patterns) > 0 {
+		result.checkpoints, err = r.refCheckpoints(ctx, repository, patterns, head, ref)
+		if err != nil {
+			return nil, fmt.Errorf("Getting refs failed: %w", err)
+		}
+		if len(result.checkpoints) == 0 {
+			return result, nil
+		}
+		for _, checkpoint := range result.checkpoints {
+			checkpoint.Settings = fingerprint
+		}
+
+		opts.Revisions = checkpointHashes(result.checkpoints)
+		if reuse {
+			var keep bool
+			opts.Revisions, keep, err = r.refRevisions(ctx, repository, result.checkpoints)
+			if err != nil {
+				return nil, fmt.Errorf("Checking checkpoints failed: %w", err)
+			}
+			if keep {
+				result.logs = r.previousLogs(repository)
+			}
+		}
+	} else if checkpoint := r.checkpoint(repository, ref); reuse && checkpoint != nil && head != "" {
+		isAncestor, err := git.IsAncestor(ctx, repository, checkpoint.GetHash(), head)
+		if err != nil {
+			return nil, fmt.Errorf("Checking checkpoint failed: %w", err)
+		}
+
+		if isAncestor {
+			result.logs = r.previousLogs(repository)
+			if checkpoint.GetHash() == head {
+				return result, nil
+			}
+
+			opts.Revisions = []string{fmt.Sprintf("%s..%s", checkpoint.GetHash(), head)}
+		} else {
+			logger.Print("History of %s was rewritten, running a full scan", repository)
+		}
+	}
+
+	rawLogs, err := git.GetLog(ctx, repository, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Getting logs failed: %w", err)
 	}
 
 	parsedLogs, err := data.Parse(rawLogs)
 	if err != nil {
-		errs <- fmt.Errorf("Parsing logs failed: %w", err)
-		return
+		return nil, fmt.Errorf("Parsing logs failed: %w", err)
 	}
 
-	localLogs = append(localLogs, parsedLogs...)
-	results <- localLogs
-}
+	for _, log := range parsedLogs {
+		log.Repository = repository
+		log.Remote = remote
+	}
 
-func (r *Report) processDir() ([]*data.Log, error) {
-	var logs []*data.Log
-	results := make(chan []*data.Log, len(r.Dir))
-	errs := make(chan error, len(r.Dir))
-	var wg sync.WaitGroup
+	if err := classify(ctx, repository, head != "", parsedLogs); err != nil {
+		return nil, fmt.Errorf("Classifying files failed: %w", err)
+	}
 
-	callback := func(path string) error {
-		wg.Add(1)
-		go r.processGitDir(path, results, errs, &wg)
-		return nil
+	if r.Effective != data.EffectiveOff {
+		parser := data.NewEffectiveParser(r.Effective == data.EffectiveMoves)
+		if err := git.StreamPatch(ctx, repository, opts, parser.ParseLine); err != nil {
+			return nil, fmt.Errorf("Getting patches failed: %w", err)
+		}
+
+		parser.Counts().Apply(parsedLogs)
 	}
 
-	err := git.WalkDirs(r.Dir, callback)
+	if err := mailmapCoAuthors(ctx, repository, parsedLogs); err != nil {
+		return nil, fmt.Errorf("Resolving co-authors failed: %w", err)
+	}
+
+	identityLogs, err := data.Filter(
+		&data.Logs{Logs: parsedLogs},
+		data.WithIdentities(settings.identities),
+		data.WithProjectMapping(settings.projects),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Resolving identities and projects failed: %w", err)
+	}
+
+	result.logs = append(result.logs, identityLogs.Logs...)
+	return result, nil
+}
+
+// refCheckpoints returns a checkpoint for each of the refs of the repository matching the
+// patterns, along with HEAD when it is detached and every ref is selected.
+func (r *Report) refCheckpoints(
+	ctx context.Context,
+	repository string,
+	patterns []string,
+	head, ref string,
+) ([]*data.Checkpoint, error) {
+	refs, err := git.ListRefs(ctx, repository, patterns)
 	if err != nil {
 		return nil, err
 	}
 
+	checkpoints := make([]*data.Checkpoint, 0, len(refs)+1)
+	for _, ref := range refs {
+		checkpoints = append(checkpoints, &data.Checkpoint{Repository: repository, Ref: ref.Name, Hash: ref.Hash})
+	}
+
+	if r.Refs.All && head != "" && ref == "HEAD" {
+		checkpoints = append(checkpoints, &data.Checkpoint{Repository: repository, Ref: ref, Hash: head})
+	}
+
+	return checkpoints, nil
+}
+
+// refRevisions returns the revisions to log for the given checkpoints, excluding the commits
+// reachable from the checkpoints of the previous report, so each commit is logged once. It
+// reports whether the logs of the previous report are kept, which is not the case when a ref
+// was rewritten and the history is scanned from scratch.
+func (r *Report) refRevisions(
+	ctx context.Context,
+	repository string,
+	checkpoints []*data.Checkpoint,
+) ([]string, bool, error) {
+	revisions := checkpointHashes(checkpoints)
+
+	var exclusions []string
+	for _, previous := range r.previous.GetCheckpoints() {
+		if previous.GetRepository() != repository {
+			continue
+		}
+
+		for _, checkpoint := range checkpoints {
+			if checkpoint.GetRef() != previous.GetRef() {
+				continue
+			}
+
+			isAncestor, err := git.IsAncestor(ctx, repository, previous.GetHash(), checkpoint.GetHash())
+			if err != nil {
+				return nil, false, err
+			}
+			if !isAncestor {
+				logger.Print("History of %s was rewritten, running a full scan", repository)
+				return revisions, false, nil
+			}
+		}
+
+		// Refs deleted since the previous report, like merged branches, still exclude the
+		// commits already reported while their commits exist.
+		exists, err := git.HasCommit(ctx, repository, previous.GetHash())
+		if err != nil {
+			return nil, false, err
+		}
+		if exists {
+			exclusions = append(exclusions, "^"+previous.GetHash())
+		}
+	}
+
+	return append(revisions, exclusions...), true, nil
+}
+
+// checkpointHashes returns the commit of each of the checkpoints.
+func checkpointHashes(checkpoints []*data.Checkpoint) []string {
+	hashes := make([]string, 0, len(checkpoints))
+	for _, checkpoint := range checkpoints {
+		hashes = append(hashes, checkpoint.GetHash())
+	}
+	return hashes
+}
+
+// walkOptions returns the options used to search the directories for repositories.
+func (r *Report) walkOptions() *git.WalkOptions {
+	return &git.WalkOptions{
+		Submodules:     r.Submodules,
+		MaxDepth:       r.MaxDepth,
+		Skip:           r.Skip,
+		FollowSymlinks: r.Symlinks,
+	}
+}
+
+// repoOutcome holds the result of processing a repository, or the error it failed with.
+type repoOutcome struct {
+	path   string
+	result *repoResult
+	err    error
+}
+
+// processDir walks the directories and processes the repositories found with a pool of
+// workers, adding each repository to the spool as it finishes. The repositories processed are
+// returned. When the context is cancelled, the repositories processed so far are kept and the
+// others keep the data of the previous report, which is loaded for that purpose in a full scan.
+// The repositories that failed are returned in a
+// FailedError and keep the data of the previous report as well, unless running in strict mode,
+// where the first failure stops the run.
+func (r *Report) processDir(ctx context.Context, spool *spool) (map[string]bool, *FailedError, error) {
+	workerCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	paths := make(chan string)
+	outcomes := make(chan *repoOutcome)
+
+	var wg sync.WaitGroup
+	for i := 0; i < r.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range paths {
+				result, err := r.processGitDir(workerCtx, path)
+				outcomes <- &repoOutcome{path: path, result: result, err: err}
+			}
+		}()
+	}
+
+	walkErr := make(chan error, 1)
+	go func() {
+		defer close(paths)
+		walkErr <- git.WalkDirs(
+			r.Dir,
+			r.walkOptions(),
+			func(path string) error {
+				select {
+				case paths <- path:
+					return nil
+				case <-workerCtx.Done():
+					return workerCtx.Err()
+				}
+			},
+		)
+	}()
+
 	go func() {
 		wg.Wait()
-		close(results)
-		close(errs)
+		close(outcomes)
 	}()
 
-	if len(errs) > 0 {
-		var collectedErrors []string
-		for err := range errs {
-			collectedErrors = append(collectedErrors, err.Error())
+	var repoErrors []*RepoError
+	var spoolErr error
+	processed := make(map[string]bool)
+	for outcome := range outcomes {
+		switch {
+		case spoolErr != nil:
+			// The outcomes are drained after a spool failure, so the workers can stop.
+		case outcome.err == nil:
+			if spoolErr = spool.add(outcome.result); spoolErr != nil {
+				cancel()
+				continue
+			}
+			processed[outcome.result.repository] = true
+		case workerCtx.Err() != nil:
+			// The repositories stopped by an interruption or by a failure in strict mode are
+			// neither processed nor failed.
+		default:
+			repoErrors = append(repoErrors, newRepoError(outcome.path, outcome.err))
+			if r.Strict {
+				cancel()
+			}
 		}
-		return nil, fmt.Errorf("Errors encountered: %s", strings.Join(collectedErrors, "; "))
 	}
 
-	for localLogs := range results {
-		logs = append(logs, localLogs...)
+	if err := <-walkErr; err != nil && workerCtx.Err() == nil {
+		return nil, nil, err
+	}
+
+	if spoolErr != nil {
+		return nil, nil, spoolErr
+	}
+
+	if r.Strict && len(repoErrors) > 0 {
+		return nil, nil, repoErrors[0]
+	}
+
+	if ctx.Err() != nil {
+		if !r.Incremental {
+			if err := r.loadInterrupted(); err != nil {
+				return nil, nil, err
+			}
+		}
+		logger.Warn("Interrupted, keeping the %d repositories processed so far", len(spool.results))
 	}
 
-	return logs, nil
+	if len(repoErrors) > 0 {
+		return processed, &FailedError{
+			Errors:       repoErrors,
+			Repositories: len(spool.results) + len(repoErrors),
+		}, nil
+	}
+
+	return processed, nil, nil
 }
 
-// save saves the report.
-func (r *Report) save(logs *data.Logs) error {
-	data, err := proto.Marshal(logs)
+// mailmapCoAuthors resolves the co-authors of the logs with the .mailmap of the repository, as
+// git only applies it to the author and the committer.
+func mailmapCoAuthors(ctx context.Context, repository string, logs []*data.Log) error {
+	var coAuthors []string
+	seen := make(map[string]bool)
+	for _, log := range logs {
+		for _, coAuthor := range log.GetCoAuthors() {
+			if !seen[coAuthor] {
+				seen[coAuthor] = true
+				coAuthors = append(coAuthors, coAuthor)
+			}
+		}
+	}
+
+	contacts := make([]string, 0, len(coAuthors))
+	for _, coAuthor := range coAuthors {
+		name, email := data.SplitIdentity(coAuthor)
+		contacts = append(contacts, fmt.Sprintf("%s <%s>", name, email))
+	}
+
+	mapped, err := git.CheckMailmap(ctx, repository, contacts)
 	if err != nil {
-		return fmt.Errorf("Marshaling logs failed: %w", err)
+		return err
+	}
+
+	resolved := make(map[string]string, len(coAuthors))
+	for i, coAuthor := range coAuthors {
+		if i < len(mapped) {
+			resolved[coAuthor] = data.ParseContact(mapped[i])
+		}
+	}
+
+	for _, log := range logs {
+		for i, coAuthor := range log.GetCoAuthors() {
+			if canonical, ok := resolved[coAuthor]; ok {
+				log.CoAuthors[i] = canonical
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkpoint returns the checkpoint of the previous report for the given repository and ref.
+func (r *Report) checkpoint(repository, ref string) *data.Checkpoint {
+	for _, checkpoint := range r.previous.GetCheckpoints() {
+		if checkpoint.GetRepository() == repository && checkpoint.GetRef() == ref {
+			return checkpoint
+		}
 	}
 
-	if _, err := os.Stat(r.Output); err == nil {
-		err = os.Remove(r.Output)
+	return nil
+}
+
+// reusable reports whether the data of the previous report for the repository can be reused,
+// which is the case when its checkpoints were made with the same configuration.
+func (r *Report) reusable(repository, fingerprint string) bool {
+	for _, checkpoint := range r.previous.GetCheckpoints() {
+		if checkpoint.GetRepository() == repository && checkpoint.GetSettings() != fingerprint {
+			return false
+		}
+	}
+
+	return true
+}
+
+// previousLogs returns the logs of the previous report that were found in the given repository.
+func (r *Report) previousLogs(repository string) []*data.Log {
+	var logs []*data.Log
+	for _, log := range r.previous.GetLogs() {
+		if log.SourceName() == repository {
+			logs = append(logs, log)
+		}
+	}
+
+	return logs
+}
+
+// keepUnprocessed carries over the logs and checkpoints of the previous report for the
+// repositories that were not processed in this run.
+func (r *Report) keepUnprocessed(stream *data.StreamWriter, processed map[string]bool) error {
+	var repositories []string
+	chunks := make(map[string][]*data.Log)
+	for _, log := range r.previous.GetLogs() {
+		if log.SourceName() == "" || processed[log.SourceName()] {
+			continue
+		}
+		if _, ok := chunks[log.GetRepository()]; !ok {
+			repositories = append(repositories, log.GetRepository())
+		}
+		chunks[log.GetRepository()] = append(chunks[log.GetRepository()], log)
+	}
+
+	for _, repository := range repositories {
+		if err := stream.WriteChunk(repository, chunks[repository]); err != nil {
+			return err
+		}
+	}
+
+	for _, checkpoint := range r.previous.GetCheckpoints() {
+		if !processed[checkpoint.GetRepository()] {
+			if err := stream.WriteCheckpoint(checkpoint); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// metadata returns the metadata header describing how the report was generated. The options
+// that are not applied to pre-captured logs are left unset for a report generated from them,
+// which records the log files instead.
+func (r *Report) metadata() (*data.Metadata, error) {
+	if len(r.FromLogs) > 0 {
+		return r.logMetadata()
+	}
+
+	dirs := make([]string, 0, len(r.Dir))
+	for _, dir := range r.Dir {
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, fmt.Errorf("Could not convert to absolute path: %w", err)
+		}
+		dirs = append(dirs, absDir)
+	}
+
+	return &data.Metadata{
+		SchemaVersion:   data.SchemaVersion,
+		GeneratedAt:     timestamppb.Now(),
+		ProdugitVersion: version.Get(),
+		Dirs:            dirs,
+		Excludes:        r.Exclude,
+		MergePolicy:     r.Merges,
+		Incremental:     r.Incremental,
+		Submodules:      r.Submodules,
+		Since:           timestamp(r.Since),
+		Until:           timestamp(r.Until),
+		Refs:            r.Refs.Patterns(),
+		EffectiveLines:  r.Effective,
+	}, nil
+}
+
+// logMetadata returns the metadata header of a report generated from pre-captured logs.
+func (r *Report) logMetadata() (*data.Metadata, error) {
+	sources := make([]string, 0, len(r.FromLogs))
+	for _, path := range r.FromLogs {
+		if path == "-" {
+			sources = append(sources, path)
+			continue
+		}
+
+		absPath, err := filepath.Abs(path)
 		if err != nil {
-			return fmt.Errorf("Removing file failed: %w", err)
+			return nil, fmt.Errorf("Could not convert to absolute path: %w", err)
 		}
+		sources = append(sources, absPath)
 	}
 
-	err = os.WriteFile(r.Output, data, 0600)
+	return &data.Metadata{
+		SchemaVersion:   data.SchemaVersion,
+		GeneratedAt:     timestamppb.Now(),
+		ProdugitVersion: version.Get(),
+		Incremental:     r.Incremental,
+		EffectiveLines:  data.EffectiveOff,
+		LogSources:      sources,
+	}, nil
+}
+
+// timestamp converts the time to a timestamp, which is nil for the zero time.
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// sameTime reports whether the timestamp holds the given time, where nil is the zero time.
+func sameTime(ts *timestamppb.Timestamp, t time.Time) bool {
+	if ts == nil {
+		return t.IsZero()
+	}
+	return ts.AsTime().Equal(t)
+}
+
+// sameStrings reports whether both slices hold the same strings in the same order.
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// sameSet reports whether both slices hold the same strings, regardless of their order.
+func sameSet(a, b []string) bool {
+	count := make(map[string]int, len(a))
+	for _, s := range a {
+		count[s]++
+	}
+	for _, s := range b {
+		count[s]--
+	}
+	for _, n := range count {
+		if n != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// save saves the report, writing the repositories in the spool one at a time, collapsed with
+// the others sharing their history, and carrying over the data of the previous report for the
+// repositories not processed.
+func (r *Report) save(spool *spool, processed map[string]bool) error {
+	metadata, err := r.metadata()
 	if err != nil {
-		return fmt.Errorf("Writing file failed: %w", err)
+		return err
+	}
+
+	plan := deduplicate(spool.results)
+
+	err = writeFileAtomic(r.Output, func(w io.Writer) error {
+		stream, err := data.NewStreamWriter(w, r.Compress, metadata)
+		if err != nil {
+			return err
+		}
+
+		err = spool.each(func(result *repoResult, logs []*data.Log) error {
+			collapse := plan[result]
+			logs = collapse.keep(result, logs)
+			if len(logs) == 0 {
+				return nil
+			}
+			return stream.WriteChunk(collapse.canonical.repository, logs)
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, result := range spool.results {
+			for _, checkpoint := range result.checkpoints {
+				if err := stream.WriteCheckpoint(checkpoint); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := r.keepUnprocessed(stream, processed); err != nil {
+			return err
+		}
+
+		return stream.Close()
+	})
+	if err != nil {
+		return err
+	}
+
+	if r.History != "" {
+		snapshot, err := SaveSnapshot(r.History, r.Output, metadata.GetGeneratedAt().AsTime())
+		if err != nil {
+			return fmt.Errorf("Saving snapshot failed: %w", err)
+		}
+		logger.Print("Saved snapshot %s", snapshot.Path)
 	}
 
 	return nil
 }
+
+// contains is a helper function to check if a slice contains a string.
+func contains(slice []string, str string) bool {
+	for _, s := range slice {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
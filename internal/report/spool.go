@@ -0,0 +1,104 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/christian-gama/produgit/internal/data"
+)
+
+// spool holds the logs of the repositories processed in a temporary file next to the output,
+// so only the logs of the repositories being processed are held in memory. The results keep
+// their checkpoints and the hashes of their commits, which are needed to collapse the
+// repositories sharing history before the report is written.
+type spool struct {
+	file    *os.File
+	stream  *data.StreamWriter
+	results []*repoResult
+	counts  []int
+}
+
+// newSpool creates the spool in the directory of the report at output.
+func newSpool(output string) (*spool, error) {
+	file, err := os.CreateTemp(filepath.Dir(output), "."+filepath.Base(output)+".*.spool")
+	if err != nil {
+		return nil, fmt.Errorf("Creating spool failed: %w", err)
+	}
+
+	stream, err := data.NewStreamWriter(file, false, nil)
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, err
+	}
+
+	return &spool{file: file, stream: stream}, nil
+}
+
+// add writes the logs of the result to the spool, releasing them from the result.
+func (s *spool) add(result *repoResult) error {
+	result.hashes = distinctHashes(result.logs)
+
+	if err := s.stream.WriteChunk(result.repository, result.logs); err != nil {
+		return fmt.Errorf("Spooling %s failed: %w", result.repository, err)
+	}
+
+	s.results = append(s.results, result)
+	s.counts = append(s.counts, len(result.logs))
+	result.logs = nil
+
+	return nil
+}
+
+// each calls fn with each result added and its logs, read back one repository at a time in the
+// order they were added.
+func (s *spool) each(fn func(result *repoResult, logs []*data.Log) error) error {
+	if err := s.stream.Close(); err != nil {
+		return fmt.Errorf("Flushing spool failed: %w", err)
+	}
+
+	reader, err := data.OpenReport(s.file.Name())
+	if err != nil {
+		return fmt.Errorf("Opening spool failed: %w", err)
+	}
+	defer reader.Close()
+
+	for i, result := range s.results {
+		logs := make([]*data.Log, 0, s.counts[i])
+		for len(logs) < s.counts[i] {
+			if !reader.Next() {
+				if err := reader.Err(); err != nil {
+					return fmt.Errorf("Reading spool failed: %w", err)
+				}
+				return fmt.Errorf("Spool ended before the logs of %s.", result.repository)
+			}
+			logs = append(logs, reader.Log())
+		}
+
+		if err := fn(result, logs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// close removes the spool.
+func (s *spool) close() error {
+	s.file.Close()
+	return os.Remove(s.file.Name())
+}
+
+// distinctHashes returns the distinct commit hashes of the logs, in the order they appear.
+func distinctHashes(logs []*data.Log) []string {
+	var hashes []string
+	seen := make(map[string]bool)
+	for _, log := range logs {
+		if log.GetHash() != "" && !seen[log.GetHash()] {
+			seen[log.GetHash()] = true
+			hashes = append(hashes, log.GetHash())
+		}
+	}
+	return hashes
+}
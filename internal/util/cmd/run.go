@@ -1,26 +1,91 @@
 package cmdutil
 
 import (
+	"bufio"
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"strings"
 )
 
 // RunAndWait executes a command and returns the output as a string.
 func RunAndWait(cmd string, args ...string) (string, error) {
-	command := exec.Command(cmd, args...)
+	return RunAndWaitContext(context.Background(), cmd, args...)
+}
+
+// RunAndWaitContext executes a command and returns the output as a string. The command is
+// killed when the context is done, in which case the error of the context is returned.
+func RunAndWaitContext(ctx context.Context, cmd string, args ...string) (string, error) {
+	command := exec.CommandContext(ctx, cmd, args...)
 	var stdout, stderr bytes.Buffer
 	command.Stdout = &stdout
 	command.Stderr = &stderr
 
 	if err := command.Run(); err != nil {
-		return "", fmt.Errorf("Command failed with error: %v", err)
+		if ctx.Err() != nil {
+			return "", fmt.Errorf("Command was stopped: %w", ctx.Err())
+		}
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("Command failed with error: %w: %s", err, message)
+		}
+		return "", fmt.Errorf("Command failed with error: %w", err)
 	}
 
 	return stdout.String(), nil
 }
 
+// StreamContext executes a command and calls handle with each line of its output, without the
+// line ending, as the output is read. The command is killed when the context is done, in which
+// case the error of the context is returned.
+func StreamContext(ctx context.Context, handle func(line string), cmd string, args ...string) error {
+	command := exec.CommandContext(ctx, cmd, args...)
+	var stderr bytes.Buffer
+	command.Stderr = &stderr
+
+	stdout, err := command.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("Command failed with error: %w", err)
+	}
+
+	if err := command.Start(); err != nil {
+		return fmt.Errorf("Command failed with error: %w", err)
+	}
+
+	reader := bufio.NewReader(stdout)
+	for {
+		line, readErr := reader.ReadString('\n')
+		if line != "" {
+			handle(strings.TrimSuffix(line, "\n"))
+		}
+		if readErr != nil {
+			if !errors.Is(readErr, io.EOF) {
+				err = readErr
+			}
+			break
+		}
+	}
+
+	if waitErr := command.Wait(); waitErr != nil {
+		err = waitErr
+	}
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("Command was stopped: %w", ctx.Err())
+		}
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return fmt.Errorf("Command failed with error: %w: %s", err, message)
+		}
+		return fmt.Errorf("Command failed with error: %w", err)
+	}
+
+	return nil
+}
+
 // Run executes a command and returns the command object.
 func Run(cmd string, args ...string) (*exec.Cmd, error) {
 	command := exec.Command(cmd, args...)
@@ -2,15 +2,29 @@ package dateutil
 
 import (
 	"fmt"
+	"strings"
 	"time"
+
+	// Embeds the IANA time zone database, so time zones can be loaded on any system.
+	_ "time/tzdata"
 )
 
+// AuthorTimezone is the timezone option that keeps the dates in the timezone of each author.
+const AuthorTimezone = "author"
+
 func ToTime(dateStr string) (time.Time, error) {
+	return ToTimeIn(dateStr, time.UTC)
+}
+
+// ToTimeIn parses the date in the given location. Dates holding an UTC offset are parsed with
+// their own offset.
+func ToTimeIn(dateStr string, loc *time.Location) (time.Time, error) {
 	if dateStr == "" {
 		return time.Time{}, nil
 	}
 
 	formats := []string{
+		"2006-01-02 15:04 -0700",
 		"2006-01-02 15:04",
 		"2006-01-02",
 		"2006-01",
@@ -18,7 +32,7 @@ func ToTime(dateStr string) (time.Time, error) {
 	}
 
 	for _, format := range formats {
-		date, err := time.Parse(format, dateStr)
+		date, err := time.ParseInLocation(format, dateStr, loc)
 		if err == nil {
 			return date, nil
 		}
@@ -30,3 +44,24 @@ func ToTime(dateStr string) (time.Time, error) {
 func ToString(date time.Time) string {
 	return date.Format("2006-01-02 15:04")
 }
+
+// ToLocation parses a timezone option. It returns nil for the author timezone, meaning each
+// date must be kept in the timezone it was recorded in, or the location for "utc", "local"
+// and IANA time zones like "America/Sao_Paulo".
+func ToLocation(tz string) (*time.Location, error) {
+	switch strings.ToLower(tz) {
+	case "", AuthorTimezone:
+		return nil, nil
+	case "utc":
+		return time.UTC, nil
+	case "local":
+		return time.Local, nil
+	}
+
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid timezone: %s.", tz)
+	}
+
+	return loc, nil
+}
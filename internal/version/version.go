@@ -0,0 +1,21 @@
+package version
+
+import "runtime/debug"
+
+// Version is the version of produgit. It can be set at build time with
+// -ldflags "-X github.com/christian-gama/produgit/internal/version.Version=<version>".
+var Version = ""
+
+// Get returns the version of produgit, falling back to the version of the module when it was
+// installed with go install, or "dev" for local builds.
+func Get() string {
+	if Version != "" {
+		return Version
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+
+	return "dev"
+}